@@ -0,0 +1,7 @@
+data "spaceship_dns_zone_file" "example" {
+  domain = "example.com"
+}
+
+output "zone_file" {
+  value = data.spaceship_dns_zone_file.example.content
+}
@@ -0,0 +1,21 @@
+data "spaceship_domain_expirations" "next_30_days" {
+  within = "720h"
+}
+
+# A failed check is reported as a warning on every plan and apply.
+check "production_domains_renew" {
+  assert {
+    condition = length(setintersection(
+      toset(data.spaceship_domain_expirations.next_30_days.at_risk_domains),
+      ["example.com", "example.net"],
+    )) == 0
+    error_message = "Production domains expiring within 30 days without renewing: ${join(", ", data.spaceship_domain_expirations.next_30_days.at_risk_domains)}"
+  }
+}
+
+output "expiring" {
+  value = {
+    for d in data.spaceship_domain_expirations.next_30_days.domains :
+    d.name => "${d.days_remaining} days, auto_renew=${d.auto_renew}"
+  }
+}
@@ -11,3 +11,15 @@ output "first_domain" {
     nameservers      = data.spaceship_domain_list.all.items[0].nameservers.hosts
   } : null
 }
+
+# Every .com domain expiring in the next 30 days without auto-renew, soonest first.
+data "spaceship_domain_list" "renew_soon" {
+  tld             = "com"
+  auto_renew      = false
+  expiring_within = "720h"
+  order_by        = "expiration_date"
+}
+
+output "renew_soon" {
+  value = [for d in data.spaceship_domain_list.renew_soon.items : "${d.name} expires ${d.expiration_date}"]
+}
@@ -0,0 +1,20 @@
+locals {
+  # Every record from the old host except its apex NS set, which Spaceship
+  # manages through spaceship_domain.nameservers.
+  imported = [
+    for record in provider::spaceship::parse_zone_file(file("${path.module}/example.com.zone"), "example.com") :
+    record if !(record.type == "NS" && record.name == "@")
+  ]
+}
+
+resource "spaceship_dns_records" "example" {
+  domain = "example.com"
+
+  records = concat(local.imported, [
+    {
+      type  = "TXT"
+      name  = "@"
+      value = "v=spf1 include:_spf.example.com ~all"
+    },
+  ])
+}
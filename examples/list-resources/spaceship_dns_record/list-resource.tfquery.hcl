@@ -0,0 +1,10 @@
+# Run `terraform query -generate-config-out=records.tf` to write an import
+# block and a spaceship_dns_record resource for every record in the zone.
+list "spaceship_dns_record" "zone" {
+  provider         = spaceship
+  include_resource = true
+
+  config {
+    domain = "example.com"
+  }
+}
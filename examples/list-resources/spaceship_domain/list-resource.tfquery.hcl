@@ -0,0 +1,11 @@
+# Run `terraform query -generate-config-out=domains.tf` to write an import
+# block and a spaceship_domain resource for every registered .com domain.
+list "spaceship_domain" "com" {
+  provider         = spaceship
+  include_resource = true
+
+  config {
+    tld              = "com"
+    lifecycle_status = "registered"
+  }
+}
@@ -0,0 +1,9 @@
+import {
+  to = spaceship_dns_record.web
+  identity = {
+    domain  = "example.com"
+    type    = "A"
+    name    = "@"
+    address = "203.0.113.10"
+  }
+}
@@ -1 +1,5 @@
+# Full composite ID
 terraform import spaceship_dns_record.web "example.com/A/@/203.0.113.10"
+
+# Type and name only, when no other A record exists at the apex
+terraform import spaceship_dns_record.web "example.com/A/@"
@@ -0,0 +1,22 @@
+# Manage only the mail records; records created elsewhere (by hand or by
+# spaceship_dns_record) are left alone.
+resource "spaceship_dns_records" "mail" {
+  domain          = "example.com"
+  deletion_policy = "additive"
+
+  records = [
+    {
+      type       = "MX"
+      name       = "@"
+      ttl        = 3600
+      exchange   = "mail.example.com"
+      preference = 10
+    },
+    {
+      type  = "TXT"
+      name  = "@"
+      ttl   = 3600
+      value = "v=spf1 include:_spf.example.com ~all"
+    }
+  ]
+}
@@ -0,0 +1,6 @@
+import {
+  to = spaceship_dns_records.example
+  identity = {
+    domain = "example.com"
+  }
+}
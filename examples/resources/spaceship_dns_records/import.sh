@@ -0,0 +1 @@
+terraform import spaceship_dns_records.example example.com
@@ -0,0 +1,32 @@
+# Two teams manage disjoint parts of one zone. Each instance only reads,
+# diffs and deletes records under its own names.
+resource "spaceship_dns_records" "platform" {
+  domain      = "example.com"
+  owned_names = ["@", "www"]
+
+  records = [
+    {
+      type    = "A"
+      name    = "@"
+      address = "192.0.2.10"
+    },
+    {
+      type  = "CNAME"
+      name  = "www"
+      cname = "example.com"
+    }
+  ]
+}
+
+resource "spaceship_dns_records" "staging" {
+  domain      = "example.com"
+  owned_names = ["staging"]
+
+  records = [
+    {
+      type    = "A"
+      name    = "api.staging"
+      address = "192.0.2.20"
+    }
+  ]
+}
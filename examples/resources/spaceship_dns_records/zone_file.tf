@@ -0,0 +1,6 @@
+# Migrate a zone exported from another DNS host. Unsupported record types and
+# invalid records are reported with their line number at plan time.
+resource "spaceship_dns_records" "migrated" {
+  domain    = "example.com"
+  zone_file = file("${path.module}/example.com.zone")
+}
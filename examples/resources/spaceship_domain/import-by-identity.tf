@@ -0,0 +1,6 @@
+import {
+  to = spaceship_domain.example
+  identity = {
+    domain = "example.com"
+  }
+}
@@ -0,0 +1,7 @@
+import {
+  to = spaceship_personal_nameserver.ns1
+  identity = {
+    domain = "example.com"
+    host   = "ns1"
+  }
+}
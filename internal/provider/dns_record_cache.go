@@ -2,9 +2,13 @@ package provider
 
 import (
 	"context"
+	"fmt"
+	"slices"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"golang.org/x/sync/singleflight"
 
 	"github.com/namecheap/go-spaceship-sdk/client"
@@ -21,17 +25,16 @@ const dnsRecordCacheFetchTimeout = 2 * time.Minute
 // spaceship_dns_record resource calls Find once per managed record during a
 // refresh; without this cache each call re-fetches and paginates the whole
 // zone, so N records in one domain cost N full zone reads. The cache collapses
-// that into one read per domain.
+// that into one read per domain. The plural spaceship_dns_records reads
+// through it too, so a plan's refresh and change preview, and every resource
+// on one domain, share a single fetch.
 //
 // Correctness rests on write-invalidation: every resource that reads through
 // the cache must call Invalidate(domain) after mutating that domain's records,
-// so a later Find re-fetches instead of serving stale data. The plural
-// spaceship_dns_records resource deliberately does not participate (neither
-// reading nor invalidating): mixing it with the singular resource on one
-// domain is documented as unsupported, so the cache does not defend against
-// that configuration. The cache lives in the provider layer (not the client)
-// so the client stays a cache-free, reusable API surface — which means the
-// client cannot invalidate on its own, and callers own that responsibility.
+// so a later Find or List re-fetches instead of serving stale data. The cache
+// lives in the provider layer (not the client) so the client stays a
+// cache-free, reusable API surface — which means the client cannot invalidate
+// on its own, and callers own that responsibility.
 type dnsRecordCache struct {
 	client *client.Client
 
@@ -48,6 +51,20 @@ type dnsRecordCache struct {
 	// when it finishes — a detached fetch that raced a write can therefore
 	// never re-cache pre-write data after the write's Invalidate.
 	gen map[string]uint64
+
+	// zones and recordClaims track which resources manage which part of a
+	// domain during this run, so ClaimZone/ClaimRecord can flag a singular
+	// record inside a zone an authoritative plural resource owns. reported
+	// keeps each overlap to a single warning however often it is re-claimed.
+	zones        map[string]map[string][]string
+	recordClaims map[string]map[string]recordClaim
+	reported     map[string]bool
+}
+
+// recordClaim is a spaceship_dns_record's stake in a domain.
+type recordClaim struct {
+	recordType string
+	name       string
 }
 
 func newDNSRecordCache(c *client.Client) *dnsRecordCache {
@@ -55,6 +72,10 @@ func newDNSRecordCache(c *client.Client) *dnsRecordCache {
 		client:  c,
 		entries: make(map[string][]client.DNSRecord),
 		gen:     make(map[string]uint64),
+
+		zones:        make(map[string]map[string][]string),
+		recordClaims: make(map[string]map[string]recordClaim),
+		reported:     make(map[string]bool),
 	}
 }
 
@@ -73,6 +94,16 @@ func (c *dnsRecordCache) Find(ctx context.Context, domain, recordType, name, sig
 	return client.DNSRecord{}, client.ErrRecordNotFound
 }
 
+// List returns all custom-group records for the domain, serving from cache
+// when warm. The slice is a copy, so callers may filter or reorder it freely.
+func (c *dnsRecordCache) List(ctx context.Context, domain string) ([]client.DNSRecord, error) {
+	records, err := c.records(ctx, domain)
+	if err != nil {
+		return nil, err
+	}
+	return slices.Clone(records), nil
+}
+
 // Invalidate drops a domain's cached records so the next Find re-fetches. Call
 // it after every successful write (create/update/delete) to that domain.
 func (c *dnsRecordCache) Invalidate(domain string) {
@@ -85,6 +116,68 @@ func (c *dnsRecordCache) Invalidate(domain string) {
 	c.sf.Forget(domain)
 }
 
+// ClaimZone registers an authoritative spaceship_dns_records on domain, scoped
+// to owned_names (nil: the whole custom group), and warns for every singular
+// record already claimed inside that scope. Additive plural resources never
+// delete records they did not write, so they do not claim the zone.
+func (c *dnsRecordCache) ClaimZone(domain string, scope []string) diag.Diagnostics {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	domain = strings.ToLower(domain)
+	if c.zones[domain] == nil {
+		c.zones[domain] = make(map[string][]string)
+	}
+	c.zones[domain][strings.Join(scope, ",")] = scope
+
+	var diags diag.Diagnostics
+	for _, claim := range c.recordClaims[domain] {
+		if scope == nil || nameInScope(claim.name, scope) {
+			c.reportOverlap(&diags, domain, claim)
+		}
+	}
+	return diags
+}
+
+// ClaimRecord registers a spaceship_dns_record on domain and warns when an
+// authoritative spaceship_dns_records already owns its name.
+func (c *dnsRecordCache) ClaimRecord(domain, recordType, name string) diag.Diagnostics {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	domain = strings.ToLower(domain)
+	claim := recordClaim{recordType: strings.ToUpper(recordType), name: strings.ToLower(name)}
+	if c.recordClaims[domain] == nil {
+		c.recordClaims[domain] = make(map[string]recordClaim)
+	}
+	c.recordClaims[domain][claim.recordType+" "+claim.name] = claim
+
+	var diags diag.Diagnostics
+	for _, scope := range c.zones[domain] {
+		if scope == nil || nameInScope(claim.name, scope) {
+			c.reportOverlap(&diags, domain, claim)
+			break
+		}
+	}
+	return diags
+}
+
+// reportOverlap adds the overlap warning once per record. Callers hold c.mu.
+func (c *dnsRecordCache) reportOverlap(diags *diag.Diagnostics, domain string, claim recordClaim) {
+	key := domain + " " + claim.recordType + " " + claim.name
+	if c.reported[key] {
+		return
+	}
+	c.reported[key] = true
+	diags.AddWarning(
+		"DNS record managed by two resources",
+		fmt.Sprintf("The spaceship_dns_record %s %s in %s lies inside the records owned by an authoritative spaceship_dns_records for the same domain. "+
+			"Each apply of spaceship_dns_records deletes records missing from its list, so the two resources will keep undoing each other. "+
+			"Move the record into spaceship_dns_records, exclude its name from owned_names, or set deletion_policy = \"additive\".",
+			claim.recordType, claim.name, domain),
+	)
+}
+
 // records returns the domain's custom-group records, serving the cached slice
 // on a hit and fetching via client.GetDNSRecords on a miss.
 func (c *dnsRecordCache) records(ctx context.Context, domain string) ([]client.DNSRecord, error) {
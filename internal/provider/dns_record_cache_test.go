@@ -6,6 +6,7 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -266,3 +267,60 @@ func TestDNSRecordCache_ConcurrentReadsCollapse(t *testing.T) {
 		t.Fatalf("expected concurrent reads to collapse into 1 fetch, got %d", got)
 	}
 }
+
+// List shares the cached fetch with Find and hands out a copy, so a caller
+// filtering its result in place cannot corrupt the cache.
+func TestDNSRecordCache_ListSharesFetchAndCopies(t *testing.T) {
+	cache, gets := newCountingRecordCache(t, []map[string]any{
+		{"type": "A", "name": "@", "ttl": 3600, "address": "1.2.3.4"},
+		{"type": "A", "name": "www", "ttl": 3600, "address": "5.6.7.8"},
+	})
+
+	first, err := cache.List(t.Context(), "example.com")
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	first[0].Address = "mutated"
+
+	if _, err := cache.Find(t.Context(), "example.com", "A", "@", "1.2.3.4"); err != nil {
+		t.Fatalf("Find after mutating the List result: %v", err)
+	}
+	if got := atomic.LoadInt64(gets); got != 1 {
+		t.Fatalf("expected 1 underlying fetch, got %d", got)
+	}
+}
+
+// A singular record inside an authoritative plural resource's scope is
+// flagged once, whichever resource claims the domain first.
+func TestDNSRecordCache_ClaimsFlagOverlapOnce(t *testing.T) {
+	t.Run("record first", func(t *testing.T) {
+		cache := newDNSRecordCache(nil)
+		if diags := cache.ClaimRecord("example.com", "a", "WWW"); len(diags) != 0 {
+			t.Fatalf("expected no diagnostics before a zone claim, got %v", diags)
+		}
+		diags := cache.ClaimZone("Example.com", nil)
+		if diags.WarningsCount() != 1 || diags.HasError() {
+			t.Fatalf("expected one warning, got %v", diags)
+		}
+		if detail := diags.Warnings()[0].Detail(); !strings.Contains(detail, "A www in example.com") {
+			t.Errorf("expected the record in the detail, got %q", detail)
+		}
+		if diags := cache.ClaimZone("example.com", nil); len(diags) != 0 {
+			t.Errorf("expected the overlap to be reported once, got %v", diags)
+		}
+	})
+
+	t.Run("zone first", func(t *testing.T) {
+		cache := newDNSRecordCache(nil)
+		cache.ClaimZone("example.com", []string{"dev"})
+		if diags := cache.ClaimRecord("example.com", "A", "www"); len(diags) != 0 {
+			t.Errorf("a record outside owned_names must not be flagged, got %v", diags)
+		}
+		if diags := cache.ClaimRecord("example.com", "A", "api.dev"); diags.WarningsCount() != 1 {
+			t.Errorf("expected one warning for a record inside owned_names, got %v", diags)
+		}
+		if diags := cache.ClaimRecord("example.org", "A", "api.dev"); len(diags) != 0 {
+			t.Errorf("claims must not leak across domains, got %v", diags)
+		}
+	})
+}
@@ -35,21 +35,6 @@ func deleteDNSRecordsWithRetry(ctx context.Context, c *client.Client, domain str
 	})
 }
 
-// clearDNSRecordsWithRetry removes every custom-group record for the domain.
-// It composes the read and delete from the per-call helpers above (mirroring
-// the SDK's ClearDNSRecords) rather than retrying the SDK composite, so a 429
-// from the delete half never re-runs an already-successful zone read.
-func clearDNSRecordsWithRetry(ctx context.Context, c *client.Client, domain string) error {
-	records, err := getDNSRecordsWithRetry(ctx, c, domain)
-	if err != nil {
-		if client.IsNotFoundError(err) {
-			return nil
-		}
-		return err
-	}
-	return deleteDNSRecordsWithRetry(ctx, c, domain, records)
-}
-
 // defaultRecordTTL is the TTL applied when a record omits one. It is the single
 // source of truth: the schema Default (recordAttributes) and the conversion
 // fallback in modelToDNSRecord both reference it.
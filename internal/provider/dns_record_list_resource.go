@@ -0,0 +1,161 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/namecheap/go-spaceship-sdk/client"
+)
+
+var (
+	_ list.ListResource              = &dnsRecordListResource{}
+	_ list.ListResourceWithConfigure = &dnsRecordListResource{}
+)
+
+func NewDNSRecordListResource() list.ListResource {
+	return &dnsRecordListResource{}
+}
+
+// dnsRecordListResource enumerates a domain's custom records for `terraform
+// query`, one spaceship_dns_record per record, so -generate-config-out can
+// write an import block and configuration for the whole zone.
+type dnsRecordListResource struct {
+	client *client.Client
+	// records is the shared per-domain read cache: a query that also
+	// refreshes records of the listed domain shares one zone fetch.
+	records *dnsRecordCache
+}
+
+type dnsRecordListConfigModel struct {
+	Domain types.String `tfsdk:"domain"`
+}
+
+func (r *dnsRecordListResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dns_record"
+}
+
+func (r *dnsRecordListResource) ListResourceConfigSchema(_ context.Context, _ list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the custom DNS records of a Spaceship-managed domain as `spaceship_dns_record` resources. Records owned by Spaceship features (e.g. URL redirect, personal nameservers) are not listed.",
+		Attributes: map[string]schema.Attribute{
+			"domain": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The domain whose records to list (for example `example.com`).",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+		},
+	}
+}
+
+func (r *dnsRecordListResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	pd, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected provider data type", fmt.Sprintf("Expected *providerData, got %T", req.ProviderData))
+		return
+	}
+
+	r.client = pd.Client
+	r.records = pd.DNSRecords
+}
+
+func (r *dnsRecordListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	if r.client == nil {
+		var diags diag.Diagnostics
+		diags.AddError("Unconfigured provider", "The Spaceship provider was not configured. Please ensure the provider block is present.")
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	var config dnsRecordListConfigModel
+	diags := req.Config.Get(ctx, &config)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+	domain := config.Domain.ValueString()
+
+	// The zone is fetched before the stream is returned: Terraform consumes
+	// the stream after List returns, when a deadline set here has lapsed.
+	records, err := r.listRecordsWithRetry(ctx, domain)
+	if err != nil {
+		diags.AddError("Spaceship API error", fmt.Sprintf("Failed to read DNS records for %s: %s", domain, err))
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	timeoutsValue, diags := nullTimeouts(ctx, req)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	stream.Results = func(push func(list.ListResult) bool) {
+		for i, record := range records {
+			if req.Limit > 0 && int64(i) >= req.Limit {
+				return
+			}
+
+			result := req.NewListResult(ctx)
+			result.DisplayName = fmt.Sprintf("%s %s %s", strings.ToUpper(record.Type), record.Name, client.RecordValueSignature(record))
+			result.Diagnostics.Append(setDNSRecordIdentity(ctx, result.Identity, domain, record)...)
+
+			if req.IncludeResource {
+				model := dnsRecordResourceModel{
+					ID:            types.StringValue(recordID(domain, record)),
+					Domain:        types.StringValue(domain),
+					AdoptExisting: types.BoolValue(true),
+					Timeouts:      timeoutsValue,
+				}
+				hydrateRecordModel(&model.dnsRecordModel, record)
+				result.Diagnostics.Append(result.Resource.Set(ctx, &model)...)
+			}
+
+			if !push(result) {
+				return
+			}
+		}
+	}
+}
+
+// listRecordsWithRetry reads the zone through the shared cache, bounded by the
+// singular resource's read timeout since a list resource has no timeouts.
+func (r *dnsRecordListResource) listRecordsWithRetry(ctx context.Context, domain string) ([]client.DNSRecord, error) {
+	ctx, cancel := context.WithTimeout(ctx, dnsRecordReadTimeout)
+	defer cancel()
+	return withRetryValue(ctx, "read DNS records", domain, func() ([]client.DNSRecord, error) {
+		return r.records.List(ctx, domain)
+	})
+}
+
+// nullTimeouts returns an unset timeouts block typed for the listed resource's
+// schema, so generated configuration keeps the defaults.
+func nullTimeouts(ctx context.Context, req list.ListRequest) (timeouts.Value, diag.Diagnostics) {
+	attrType, diags := req.ResourceSchema.TypeAtPath(ctx, path.Root("timeouts"))
+	if diags.HasError() {
+		return timeouts.Value{}, diags
+	}
+	objectType, ok := attrType.(attr.TypeWithAttributeTypes)
+	if !ok {
+		diags.AddError("Unexpected timeouts type", fmt.Sprintf("Expected an object type, got %T", attrType))
+		return timeouts.Value{}, diags
+	}
+	return timeouts.Value{Object: types.ObjectNull(objectType.AttributeTypes())}, diags
+}
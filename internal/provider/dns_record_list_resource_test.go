@@ -0,0 +1,109 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// newDNSRecordListRequest builds the request Terraform sends for a list block with the
+// given domain, for the spaceship_dns_record resource.
+func newDNSRecordListRequest(t *testing.T, domain string, includeResource bool, limit int64) list.ListRequest {
+	t.Helper()
+	ctx := context.Background()
+
+	r := &dnsRecordResource{}
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+	identityResp := &fwresource.IdentitySchemaResponse{}
+	r.IdentitySchema(ctx, fwresource.IdentitySchemaRequest{}, identityResp)
+
+	configResp := &list.ListResourceSchemaResponse{}
+	(&dnsRecordListResource{}).ListResourceConfigSchema(ctx, list.ListResourceSchemaRequest{}, configResp)
+	config := tfsdk.Config{
+		Schema: configResp.Schema,
+		Raw: tftypes.NewValue(configResp.Schema.Type().TerraformType(ctx), map[string]tftypes.Value{
+			"domain": tftypes.NewValue(tftypes.String, domain),
+		}),
+	}
+
+	return list.ListRequest{
+		Config:                 config,
+		IncludeResource:        includeResource,
+		Limit:                  limit,
+		ResourceSchema:         schemaResp.Schema,
+		ResourceIdentitySchema: identityResp.IdentitySchema,
+	}
+}
+
+func TestDNSRecordListResource_List(t *testing.T) {
+	c := newListClient(t, 0, []map[string]any{
+		{"type": "A", "name": "www", "ttl": 600, "address": "192.0.2.1"},
+		{"type": "MX", "name": "@", "ttl": 3600, "exchange": "mail.example.com", "preference": 10},
+	})
+	r := &dnsRecordListResource{client: c, records: newDNSRecordCache(c)}
+	ctx := context.Background()
+
+	stream := &list.ListResultsStream{}
+	r.List(ctx, newDNSRecordListRequest(t, "example.com", true, 0), stream)
+
+	var ids []string
+	for result := range stream.Results {
+		if result.Diagnostics.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", result.Diagnostics)
+		}
+
+		var state dnsRecordResourceModel
+		if diags := result.Resource.Get(ctx, &state); diags.HasError() {
+			t.Fatalf("resource.Get: %v", diags)
+		}
+		var identityType, identityName types.String
+		result.Identity.GetAttribute(ctx, path.Root("type"), &identityType)
+		result.Identity.GetAttribute(ctx, path.Root("name"), &identityName)
+		if identityType.ValueString() != state.Type.ValueString() || identityName.ValueString() != state.Name.ValueString() {
+			t.Errorf("identity %s %s does not match resource %s %s", identityType, identityName, state.Type, state.Name)
+		}
+		if !state.AdoptExisting.ValueBool() || !state.Timeouts.IsNull() {
+			t.Errorf("expected defaults for adopt_existing and timeouts, got %v and %v", state.AdoptExisting, state.Timeouts)
+		}
+		ids = append(ids, state.ID.ValueString())
+	}
+
+	want := []string{"example.com/A/www/192.0.2.1", "example.com/MX/@/mail.example.com|10"}
+	if len(ids) != len(want) {
+		t.Fatalf("ids = %v, want %v", ids, want)
+	}
+	for i := range want {
+		if ids[i] != want[i] {
+			t.Errorf("ids[%d] = %q, want %q", i, ids[i], want[i])
+		}
+	}
+}
+
+func TestDNSRecordListResource_ListHonorsLimit(t *testing.T) {
+	c := newListClient(t, 0, []map[string]any{
+		{"type": "A", "name": "www", "ttl": 600, "address": "192.0.2.1"},
+		{"type": "A", "name": "www", "ttl": 600, "address": "192.0.2.2"},
+	})
+	r := &dnsRecordListResource{client: c, records: newDNSRecordCache(c)}
+
+	stream := &list.ListResultsStream{}
+	r.List(context.Background(), newDNSRecordListRequest(t, "example.com", false, 1), stream)
+
+	count := 0
+	for result := range stream.Results {
+		if result.Diagnostics.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", result.Diagnostics)
+		}
+		count++
+	}
+	if count != 1 {
+		t.Errorf("got %d results, want 1", count)
+	}
+}
@@ -0,0 +1,133 @@
+package provider
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+
+	"github.com/namecheap/go-spaceship-sdk/client"
+)
+
+// dnsRecordRegistry tracks which resource claimed each record identity
+// (client.RecordKey) per domain during one provider process, i.e. one
+// Terraform command. duplicateRecordsValidator only sees a single records
+// list; the registry catches the same record declared by two resources —
+// two spaceship_dns_record, or a spaceship_dns_record and a
+// spaceship_dns_records — which the API's upsert would otherwise let adopt
+// each other's record, so that destroying either deletes it for both.
+//
+// Write claims are taken in Create and Update, right before the write: those
+// run once per resource per apply, so a repeated claim always means a second
+// resource. ModifyPlan cannot take them: during an apply Terraform re-plans
+// every changed resource right before applying it, so a claim there would
+// collide with the same resource's Create. Write claims are never released: a
+// resource that is destroyed in the same apply never claimed (Delete does not
+// claim), so it cannot block its successor.
+//
+// Write claims miss a new resource duplicating one already in state that this
+// apply leaves unchanged. For that, Read records the records in state under
+// the resource's ID (Record), and ModifyPlan of a planned create checks them
+// without registering anything (Check). A resource planned for destroy
+// releases its read claims (Release) so that it does not block a successor.
+type dnsRecordRegistry struct {
+	mu sync.Mutex
+	// claims maps domain → RecordKey → a description of the claimant.
+	claims map[string]map[string]string
+	// read maps domain → RecordKey → the resource in state that holds it.
+	read map[string]map[string]readClaim
+}
+
+type readClaim struct {
+	id       string
+	claimant string
+}
+
+func newDNSRecordRegistry() *dnsRecordRegistry {
+	return &dnsRecordRegistry{
+		claims: make(map[string]map[string]string),
+		read:   make(map[string]map[string]readClaim),
+	}
+}
+
+// Claim registers records under claimant and returns an error diagnostic for
+// every record another resource already claimed in this run. Records that do
+// not conflict are claimed even when others do; the caller aborts anyway.
+func (r *dnsRecordRegistry) Claim(domain, claimant string, records []client.DNSRecord) diag.Diagnostics {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	domain = strings.ToLower(domain)
+	if r.claims[domain] == nil {
+		r.claims[domain] = make(map[string]string)
+	}
+
+	var diags diag.Diagnostics
+	for _, record := range records {
+		key := client.RecordKey(record)
+		if owner, ok := r.claims[domain][key]; ok {
+			addDuplicateRecordError(&diags, claimant, owner, domain, record)
+			continue
+		}
+		r.claims[domain][key] = claimant
+	}
+	return diags
+}
+
+// Record notes that the resource with the given ID holds records in state. It
+// never conflicts: two resources in state declaring the same record are
+// reported by Claim on their next write.
+func (r *dnsRecordRegistry) Record(domain, id, claimant string, records []client.DNSRecord) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	domain = strings.ToLower(domain)
+	if r.read[domain] == nil {
+		r.read[domain] = make(map[string]readClaim)
+	}
+	for _, record := range records {
+		r.read[domain][client.RecordKey(record)] = readClaim{id: id, claimant: claimant}
+	}
+}
+
+// Release drops every read claim held by the resource with the given ID.
+func (r *dnsRecordRegistry) Release(id string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, keys := range r.read {
+		for key, claim := range keys {
+			if claim.id == id {
+				delete(keys, key)
+			}
+		}
+	}
+}
+
+// Check returns an error diagnostic for every record a resource in state holds,
+// as seen by Record, without claiming anything. It is meant for ModifyPlan of
+// a planned create, which has no state of its own to collide with.
+func (r *dnsRecordRegistry) Check(domain, claimant string, records []client.DNSRecord) diag.Diagnostics {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	domain = strings.ToLower(domain)
+	var diags diag.Diagnostics
+	for _, record := range records {
+		if claim, ok := r.read[domain][client.RecordKey(record)]; ok {
+			addDuplicateRecordError(&diags, claimant, claim.claimant, domain, record)
+		}
+	}
+	return diags
+}
+
+func addDuplicateRecordError(diags *diag.Diagnostics, claimant, owner, domain string, record client.DNSRecord) {
+	diags.AddError(
+		"Duplicate DNS Record Across Resources",
+		fmt.Sprintf("%s declares the %s record %q (%s) in %s, which %s already manages in this configuration. "+
+			"The Spaceship API stores the record once, so both resources would adopt it and destroying either would delete it for both. "+
+			"Remove one of the declarations.",
+			claimant, strings.ToUpper(record.Type), record.Name, client.RecordValueSignature(record), domain, owner),
+	)
+}
@@ -0,0 +1,76 @@
+package provider
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/namecheap/go-spaceship-sdk/client"
+)
+
+func TestDNSRecordRegistry_RejectsSecondClaim(t *testing.T) {
+	registry := newDNSRecordRegistry()
+	www := client.DNSRecord{Type: "A", Name: "www", TTL: 3600, Address: "192.0.2.1"}
+
+	if diags := registry.Claim("example.com", "first", []client.DNSRecord{www}); diags.HasError() {
+		t.Fatalf("first claim must succeed, got %v", diags)
+	}
+
+	// Identity ignores TTL and case, like client.RecordKey.
+	again := client.DNSRecord{Type: "a", Name: "WWW", TTL: 300, Address: "192.0.2.1"}
+	diags := registry.Claim("Example.com", "second", []client.DNSRecord{again})
+	if diags.ErrorsCount() != 1 {
+		t.Fatalf("expected one error, got %v", diags)
+	}
+	detail := diags.Errors()[0].Detail()
+	if !strings.Contains(detail, "second declares") || !strings.Contains(detail, "which first already manages") {
+		t.Errorf("expected both claimants in the detail, got %q", detail)
+	}
+}
+
+func TestDNSRecordRegistry_DistinctRecordsDoNotConflict(t *testing.T) {
+	registry := newDNSRecordRegistry()
+	www := client.DNSRecord{Type: "A", Name: "www", Address: "192.0.2.1"}
+
+	registry.Claim("example.com", "first", []client.DNSRecord{www})
+
+	cases := map[string]struct {
+		domain string
+		record client.DNSRecord
+	}{
+		"other data":   {domain: "example.com", record: client.DNSRecord{Type: "A", Name: "www", Address: "192.0.2.2"}},
+		"other domain": {domain: "example.org", record: www},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diags := registry.Claim(tc.domain, "second", []client.DNSRecord{tc.record}); diags.HasError() {
+				t.Errorf("expected no conflict, got %v", diags)
+			}
+		})
+	}
+}
+
+func TestDNSRecordRegistry_CheckRejectsRecordInState(t *testing.T) {
+	registry := newDNSRecordRegistry()
+	www := client.DNSRecord{Type: "A", Name: "www", TTL: 3600, Address: "192.0.2.1"}
+	api := client.DNSRecord{Type: "A", Name: "api", TTL: 3600, Address: "192.0.2.2"}
+
+	registry.Record("example.com", "example.com/A/www/192.0.2.1", "existing", []client.DNSRecord{www})
+
+	diags := registry.Check("Example.com", "new", []client.DNSRecord{www, api})
+	if diags.ErrorsCount() != 1 {
+		t.Fatalf("expected one error, got %v", diags)
+	}
+	if detail := diags.Errors()[0].Detail(); !strings.Contains(detail, "new declares") || !strings.Contains(detail, "which existing already manages") {
+		t.Errorf("expected both claimants in the detail, got %q", detail)
+	}
+
+	// Check registers nothing, so the same resource's Create can still claim.
+	if diags := registry.Claim("example.com", "new", []client.DNSRecord{api}); diags.HasError() {
+		t.Errorf("Check must not claim, got %v", diags)
+	}
+
+	registry.Release("example.com/A/www/192.0.2.1")
+	if diags := registry.Check("example.com", "new", []client.DNSRecord{www}); diags.HasError() {
+		t.Errorf("a released record must not conflict, got %v", diags)
+	}
+}
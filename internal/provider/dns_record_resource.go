@@ -5,30 +5,38 @@ import (
 	"errors"
 	"fmt"
 	"maps"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 
 	"github.com/namecheap/go-spaceship-sdk/client"
 )
 
-// Create and delete make one rate-limitable call; update makes two (cache
-// find + upsert); read makes one zone fetch through the shared cache. Each
+// Create makes one rate-limitable call, or two with adopt_existing = false
+// (cache find + upsert); update makes two (cache find + upsert); delete makes
+// one; read makes one zone fetch through the shared cache. Each
 // default covers the calls' throttling windows plus at least a minute of
 // slack so the last window's wait and the retried call still fit. See
 // internal/docs/rate-limits.md.
 const (
-	dnsRecordCreateTimeout = 2 * rateLimitWindow
+	dnsRecordCreateTimeout = 2*rateLimitWindow + time.Minute
 	dnsRecordReadTimeout   = rateLimitWindow + time.Minute
 	dnsRecordUpdateTimeout = 2*rateLimitWindow + time.Minute
 	dnsRecordDeleteTimeout = rateLimitWindow + time.Minute
@@ -44,12 +52,15 @@ type dnsRecordResource struct {
 	// so N records in one domain cost one zone fetch instead of N; every write
 	// path invalidates the domain so later reads never serve stale data.
 	records *dnsRecordCache
+	// claims rejects a second resource creating the same record in one run.
+	claims *dnsRecordRegistry
 }
 
 type dnsRecordResourceModel struct {
-	ID       types.String   `tfsdk:"id"`
-	Domain   types.String   `tfsdk:"domain"`
-	Timeouts timeouts.Value `tfsdk:"timeouts"`
+	ID            types.String   `tfsdk:"id"`
+	Domain        types.String   `tfsdk:"domain"`
+	AdoptExisting types.Bool     `tfsdk:"adopt_existing"`
+	Timeouts      timeouts.Value `tfsdk:"timeouts"`
 
 	dnsRecordModel
 }
@@ -77,6 +88,12 @@ func (r *dnsRecordResource) Schema(ctx context.Context, _ resource.SchemaRequest
 				stringplanmodifier.UseStateForUnknown(),
 			},
 		},
+		"adopt_existing": schema.BoolAttribute{
+			Optional:            true,
+			Computed:            true,
+			MarkdownDescription: "Whether creating this resource may take over a record with the same type, name, and data that already exists in the zone. Defaults to `true`: the record is adopted, and destroying this resource later deletes it. Set to `false` to fail instead, with the `terraform import` ID of the existing record in the error. Only affects creation.",
+			Default:             booldefault.StaticBool(true),
+		},
 	}
 	maps.Copy(attrs, recordAttributes())
 
@@ -84,8 +101,9 @@ func (r *dnsRecordResource) Schema(ctx context.Context, _ resource.SchemaRequest
 	// matched by (type, name, data), so changing any of those produces a new
 	// record. Every attribute except `ttl` triggers Replace; `ttl` is the sole
 	// in-place mutable field and is handled by Update via the upsert endpoint.
+	// adopt_existing only steers Create, so changing it is a state-only update.
 	for attrName, attr := range attrs {
-		if attrName == "id" || attrName == "ttl" {
+		if attrName == "id" || attrName == "ttl" || attrName == "adopt_existing" {
 			continue
 		}
 		attrs[attrName] = withRequiresReplace(attr)
@@ -134,6 +152,7 @@ func (r *dnsRecordResource) Configure(_ context.Context, req resource.ConfigureR
 	}
 	r.client = pd.Client
 	r.records = pd.DNSRecords
+	r.claims = pd.DNSRecordClaims
 }
 
 func (r *dnsRecordResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -156,13 +175,14 @@ func (r *dnsRecordResource) Create(ctx context.Context, req resource.CreateReque
 
 	domain := plan.Domain.ValueString()
 
-	// No "fetch existing record before creating" / adopt-on-create logic here
-	// by design: the API's upsert endpoint (used by CreateDNSRecord below) is
-	// idempotent for records with matching (type, name, data) — see the
-	// docstring on client.CreateDNSRecord. A matching pre-existing record is
-	// transparently adopted; only conflict cases (e.g. CNAME with a different
-	// target at the same hostname) error, and `terraform import` is the right
-	// path for those. Verified by TestAccDNSRecord_createWhenIdenticalExists.
+	// By default there is no "fetch existing record before creating" step:
+	// the API's upsert endpoint (used by CreateDNSRecord below) is idempotent
+	// for records with matching (type, name, data) — see the docstring on
+	// client.CreateDNSRecord. A matching pre-existing record is transparently
+	// adopted; only conflict cases (e.g. CNAME with a different target at the
+	// same hostname) error, and `terraform import` is the right path for
+	// those. Verified by TestAccDNSRecord_createWhenIdenticalExists.
+	// adopt_existing = false opts into the lookup, see refuseExistingRecord.
 
 	// modelToDNSRecord handles every supported record type: A, AAAA, ALIAS,
 	// CAA, CNAME, HTTPS, MX, NS, PTR, SRV, SVCB, TLSA, TXT. It emits attribute
@@ -173,6 +193,20 @@ func (r *dnsRecordResource) Create(ctx context.Context, req resource.CreateReque
 		return
 	}
 
+	// Upsert adoption is deliberate for records that exist outside this
+	// configuration, but not for one another resource declares as well.
+	resp.Diagnostics.Append(r.claims.Claim(domain, "spaceship_dns_record "+recordID(domain, record), []client.DNSRecord{record})...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !plan.AdoptExisting.ValueBool() {
+		resp.Diagnostics.Append(r.refuseExistingRecord(ctx, domain, record)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	if err := r.saveRecordWithRetry(ctx, domain, record); err != nil {
 		resp.Diagnostics.AddError("Spaceship API error", fmt.Sprintf("Failed to create DNS record: %s", err))
 		return
@@ -185,7 +219,33 @@ func (r *dnsRecordResource) Create(ctx context.Context, req resource.CreateReque
 	plan.ID = types.StringValue(recordID(domain, record))
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(setDNSRecordIdentity(ctx, resp.Identity, domain, record)...)
+}
+
+// refuseExistingRecord fails a strict (adopt_existing = false) Create when the
+// record is already in the zone, pointing at `terraform import` instead: an
+// adopted record would be deleted by a destroy although Terraform never
+// created it.
+func (r *dnsRecordResource) refuseExistingRecord(ctx context.Context, domain string, record client.DNSRecord) diag.Diagnostics {
+	var diags diag.Diagnostics
 
+	_, err := r.findRecordWithRetry(ctx, domain, strings.ToUpper(record.Type), strings.ToLower(record.Name), client.RecordValueSignature(record))
+	if errors.Is(err, client.ErrRecordNotFound) {
+		return diags
+	}
+	if err != nil {
+		diags.AddError("Spaceship API error", fmt.Sprintf("Failed to look up existing DNS record for %s: %s", domain, err))
+		return diags
+	}
+
+	diags.AddError(
+		"DNS record already exists",
+		fmt.Sprintf("The %s record %q with this data already exists in %s and adopt_existing is false, so it was not taken over. "+
+			"To manage it with this resource, import it:\n\n  terraform import <resource address> %q\n\n"+
+			"or set adopt_existing = true to adopt it on create.",
+			strings.ToUpper(record.Type), record.Name, domain, recordID(domain, record)),
+	)
+	return diags
 }
 
 // saveRecordWithRetry upserts the record and invalidates the domain's cache.
@@ -296,6 +356,8 @@ func (r *dnsRecordResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
+	resp.Diagnostics.Append(r.records.ClaimRecord(domain, recordType, name)...)
+
 	record, err := r.findRecordWithRetry(ctx, domain, recordType, name, signature)
 	if errors.Is(err, client.ErrRecordNotFound) {
 		// Record no longer exists in the custom group — drop it from state so
@@ -309,8 +371,16 @@ func (r *dnsRecordResource) Read(ctx context.Context, req resource.ReadRequest,
 	}
 
 	state.Domain = types.StringValue(domain)
+	// Null after an import or in state written before adopt_existing existed;
+	// backfill the default so neither plans a spurious update.
+	if state.AdoptExisting.IsNull() {
+		state.AdoptExisting = types.BoolValue(true)
+	}
 	hydrateRecordModel(&state.dnsRecordModel, record)
+	// Lets ModifyPlan reject a new resource that declares this record too.
+	r.claims.Record(domain, state.ID.ValueString(), "spaceship_dns_record "+state.ID.ValueString(), []client.DNSRecord{record})
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(setDNSRecordIdentity(ctx, resp.Identity, domain, record)...)
 }
 
 func (r *dnsRecordResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -320,7 +390,7 @@ func (r *dnsRecordResource) Update(ctx context.Context, req resource.UpdateReque
 	}
 
 	// Schema marks every non-ttl attribute RequiresReplace, so Update runs
-	// only for ttl and/or timeouts-block changes. Re-fetch the record by
+	// only for ttl, adopt_existing and/or timeouts-block changes. Re-fetch the record by
 	// identity to recover its full data, mutate the ttl, and re-upsert.
 	var plan, state dnsRecordResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
@@ -338,11 +408,17 @@ func (r *dnsRecordResource) Update(ctx context.Context, req resource.UpdateReque
 		return
 	}
 
-	// The timeouts block is client-side only: if ttl is unchanged there is
-	// nothing to write, so skip the lookup and upsert entirely.
+	// The timeouts block and adopt_existing are client-side only: if ttl is
+	// unchanged there is nothing to write, so skip the lookup and upsert.
 	if plan.TTL.Equal(state.TTL) {
+		record, recordDiags := modelToDNSRecord(state.dnsRecordModel, path.Empty())
+		resp.Diagnostics.Append(recordDiags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
 		plan.ID = state.ID
 		resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+		resp.Diagnostics.Append(setDNSRecordIdentity(ctx, resp.Identity, domain, record)...)
 		return
 	}
 
@@ -376,13 +452,225 @@ func (r *dnsRecordResource) Update(ctx context.Context, req resource.UpdateReque
 	plan.ID = state.ID
 	hydrateRecordModel(&plan.dnsRecordModel, record)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(setDNSRecordIdentity(ctx, resp.Identity, domain, record)...)
 }
 
 func (r *dnsRecordResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	// The import string is the full composite ID (domain/TYPE/name/<signature>).
 	// Passthrough writes it to state.ID; Terraform then calls Read which parses
 	// the ID and hydrates the rest of the attributes.
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	//
+	// A shorter domain/TYPE/name ID is resolved against the live zone, since
+	// few signatures (HTTPS, SVCB, TLSA) can be written by hand.
+	if req.ID != "" {
+		if _, _, _, _, ok := parseRecordID(req.ID); ok {
+			resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+			return
+		}
+		domain, recordType, name, ok := parseShortRecordID(req.ID)
+		if !ok {
+			resp.Diagnostics.AddError(
+				"Invalid import ID",
+				fmt.Sprintf("Expected format domain/TYPE/name or domain/TYPE/name/<signature>, got %q", req.ID),
+			)
+			return
+		}
+		id, diags := r.resolveRecordID(ctx, domain, recordType, name)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+		return
+	}
+
+	// Import by identity: rebuild the composite ID from the structured
+	// attributes, so users never hand-build the signature.
+	id, diags := recordIDFromIdentity(ctx, req.Identity)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+}
+
+// parseShortRecordID splits a signature-less import ID (domain/TYPE/name).
+func parseShortRecordID(id string) (domain, recordType, name string, ok bool) {
+	parts := strings.Split(id, "/")
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return "", "", "", false
+	}
+	return parts[0], parts[1], parts[2], true
+}
+
+// resolveRecordID looks up the records of the given type and name in the live
+// zone and returns the full composite ID when exactly one matches. Several
+// matches (e.g. round-robin A records) fail with every candidate ID, so the
+// user can pick one and import it by its full ID.
+func (r *dnsRecordResource) resolveRecordID(ctx context.Context, domain, recordType, name string) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if r.client == nil {
+		diags.AddError("Unconfigured provider", "The Spaceship provider was not configured. Please ensure the provider block is present.")
+		return "", diags
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, dnsRecordReadTimeout)
+	defer cancel()
+
+	records, err := withRetryValue(ctx, "read DNS record", domain, func() ([]client.DNSRecord, error) {
+		return r.records.List(ctx, domain)
+	})
+	if err != nil {
+		diags.AddError("Spaceship API error", fmt.Sprintf("Failed to read DNS records for %s: %s", domain, err))
+		return "", diags
+	}
+
+	var candidates []string
+	for _, record := range records {
+		if strings.EqualFold(record.Type, recordType) && strings.EqualFold(record.Name, name) {
+			candidates = append(candidates, recordID(domain, record))
+		}
+	}
+	slices.Sort(candidates)
+
+	switch len(candidates) {
+	case 0:
+		diags.AddError(
+			"DNS record not found",
+			fmt.Sprintf("No %s record named %q exists in the custom DNS group of %s.", strings.ToUpper(recordType), name, domain),
+		)
+		return "", diags
+	case 1:
+		return candidates[0], diags
+	default:
+		diags.AddError(
+			"Multiple DNS records match",
+			fmt.Sprintf("%d %s records named %q exist in %s. Import one of them by its full ID:\n\n  %s",
+				len(candidates), strings.ToUpper(recordType), name, domain, strings.Join(candidates, "\n  ")),
+		)
+		return "", diags
+	}
+}
+
+// IdentitySchema exposes the record's identity as structured attributes:
+// domain, type and name, plus the per-type data fields the composite ID packs
+// into its signature. Only the fields the record type uses are set.
+func (r *dnsRecordResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	attributes := map[string]identityschema.Attribute{
+		"domain": identityschema.StringAttribute{
+			RequiredForImport: true,
+			Description:       "The domain name the record belongs to.",
+		},
+		"type": identityschema.StringAttribute{
+			RequiredForImport: true,
+			Description:       "DNS record type, uppercase.",
+		},
+		"name": identityschema.StringAttribute{
+			RequiredForImport: true,
+			Description:       "Record host, lowercase. `@` for the zone apex.",
+		},
+	}
+	for name, attrType := range dnsRecordIdentityAttrTypes() {
+		if _, ok := attributes[name]; ok {
+			continue
+		}
+		description := fmt.Sprintf("The record's `%s`, for record types that use it.", name)
+		if attrType.Equal(types.Int64Type) {
+			attributes[name] = identityschema.Int64Attribute{OptionalForImport: true, Description: description}
+		} else {
+			attributes[name] = identityschema.StringAttribute{OptionalForImport: true, Description: description}
+		}
+	}
+	resp.IdentitySchema = identityschema.Schema{Attributes: attributes}
+}
+
+// dnsRecordIdentityAttrTypes is the identity twin of dnsRecordObjectType:
+// every record attribute except ttl, which does not identify a record, plus
+// the domain.
+func dnsRecordIdentityAttrTypes() map[string]attr.Type {
+	attrTypes := maps.Clone(dnsRecordObjectType.AttrTypes)
+	delete(attrTypes, "ttl")
+	attrTypes["domain"] = types.StringType
+	return attrTypes
+}
+
+// dnsRecordIdentity builds the identity object for a record. The fields are
+// case-folded the way client.RecordValueSignature folds them, so the identity
+// set on create does not change when the API echoes the record back in a
+// different case — the framework rejects an identity that changes on read.
+func dnsRecordIdentity(ctx context.Context, domain string, record client.DNSRecord) (types.Object, diag.Diagnostics) {
+	record.Type = strings.ToUpper(record.Type)
+	record.Name = strings.ToLower(record.Name)
+	record.TTL = 0
+	for _, field := range []*string{
+		&record.Address, &record.AliasName, &record.CName, &record.Tag, &record.Scheme,
+		&record.TargetName, &record.SvcParams, &record.Exchange, &record.Nameserver,
+		&record.Pointer, &record.Service, &record.Protocol, &record.Target,
+	} {
+		*field = strings.ToLower(*field)
+	}
+	// TXT values are case-sensitive; CAA values are not.
+	if record.Type != "TXT" {
+		record.Value = strings.ToLower(record.Value)
+	}
+	record.AssociationData = strings.ReplaceAll(strings.ToLower(record.AssociationData), " ", "")
+
+	var model dnsRecordModel
+	hydrateRecordModel(&model, record)
+	object, diags := types.ObjectValueFrom(ctx, dnsRecordObjectType.AttrTypes, model)
+	if diags.HasError() {
+		return types.ObjectNull(dnsRecordIdentityAttrTypes()), diags
+	}
+
+	attributes := object.Attributes()
+	delete(attributes, "ttl")
+	attributes["domain"] = types.StringValue(domain)
+	identity, objectDiags := types.ObjectValue(dnsRecordIdentityAttrTypes(), attributes)
+	diags.Append(objectDiags...)
+	return identity, diags
+}
+
+// setDNSRecordIdentity writes the record's identity to a CRUD response.
+func setDNSRecordIdentity(ctx context.Context, identity *tfsdk.ResourceIdentity, domain string, record client.DNSRecord) diag.Diagnostics {
+	value, diags := dnsRecordIdentity(ctx, domain, record)
+	if diags.HasError() {
+		return diags
+	}
+	diags.Append(identity.Set(ctx, value)...)
+	return diags
+}
+
+// recordIDFromIdentity is the inverse of dnsRecordIdentity: it converts an
+// import identity back into the composite ID Read expects. Missing per-type
+// fields surface as the same diagnostics a configuration would get.
+func recordIDFromIdentity(ctx context.Context, identity *tfsdk.ResourceIdentity) (string, diag.Diagnostics) {
+	var object types.Object
+	diags := identity.Get(ctx, &object)
+	if diags.HasError() {
+		return "", diags
+	}
+
+	attributes := object.Attributes()
+	domain, _ := attributes["domain"].(types.String)
+	delete(attributes, "domain")
+	attributes["ttl"] = types.Int64Null()
+	recordObject, objectDiags := types.ObjectValue(dnsRecordObjectType.AttrTypes, attributes)
+	diags.Append(objectDiags...)
+	if diags.HasError() {
+		return "", diags
+	}
+
+	var model dnsRecordModel
+	diags.Append(recordObject.As(ctx, &model, basetypes.ObjectAsOptions{})...)
+	if diags.HasError() {
+		return "", diags
+	}
+	record, recordDiags := modelToDNSRecord(model, path.Empty())
+	diags.Append(recordDiags...)
+	if diags.HasError() {
+		return "", diags
+	}
+	return recordID(domain.ValueString(), record), diags
 }
 
 // parseRecordID is the inverse of recordID. It returns the components needed
@@ -412,3 +700,46 @@ func (r *dnsRecordResource) ConfigValidators(_ context.Context) []resource.Confi
 	}
 	return adapters
 }
+
+// ModifyPlan registers the planned record with the shared cache, so a record
+// that an authoritative spaceship_dns_records would delete is flagged at plan
+// time even before it exists; Read does the same for records in state. A
+// planned create is also checked against the records other resources hold in
+// state (see dnsRecordRegistry), and a planned destroy releases this one's.
+func (r *dnsRecordResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if r.records == nil {
+		return
+	}
+	if req.Plan.Raw.IsNull() {
+		var id types.String
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("id"), &id)...)
+		r.claims.Release(id.ValueString())
+		return
+	}
+
+	var plan dnsRecordResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if plan.Domain.IsUnknown() || plan.Type.IsUnknown() || plan.Name.IsUnknown() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.records.ClaimRecord(plan.Domain.ValueString(), plan.Type.ValueString(), plan.Name.ValueString())...)
+
+	if !req.State.Raw.IsNull() {
+		return
+	}
+	object, diags := types.ObjectValueFrom(ctx, dnsRecordObjectType.AttrTypes, plan.dnsRecordModel)
+	if diags.HasError() || recordHasUnknownAttribute(object) {
+		return
+	}
+	record, diags := modelToDNSRecord(plan.dnsRecordModel, path.Empty())
+	if diags.HasError() {
+		// The schema validators report these.
+		return
+	}
+	domain := plan.Domain.ValueString()
+	resp.Diagnostics.Append(r.claims.Check(domain, "spaceship_dns_record "+recordID(domain, record), []client.DNSRecord{record})...)
+}
@@ -4,11 +4,16 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"regexp"
 	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
 	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
 
 	"github.com/namecheap/go-spaceship-sdk/client"
 )
@@ -143,6 +148,14 @@ resource "spaceship_dns_record" "test" {
 				ImportState:       true,
 				ImportStateVerify: true,
 			},
+			// 6. Import it by domain/TYPE/name — the only record of its type
+			// and name, so it resolves to the same composite ID.
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateId:     fmt.Sprintf("%s/%s/%s", domain, tc.recordType, tc.recordName),
+				ImportStateVerify: true,
+			},
 		},
 		CheckDestroy: testAccCheckDNSRecordAbsent(domain, tc.recordType, tc.recordName),
 	})
@@ -616,3 +629,189 @@ resource "spaceship_dns_record" "test" {
 		CheckDestroy: testAccCheckDNSRecordAbsent(domain, "A", recordName),
 	})
 }
+
+// TestAccDNSRecord_duplicateAcrossResourcesFailsCreate verifies that two
+// resources declaring the same record fail the second Create instead of
+// silently sharing one API record.
+func TestAccDNSRecord_duplicateAcrossResourcesFailsCreate(t *testing.T) {
+	testAccPreCheck(t)
+
+	domain := testAccDomainValue()
+	recordName := testAccRecordPrefix() + "-dup"
+
+	config := fmt.Sprintf(`
+provider "spaceship" {}
+
+resource "spaceship_dns_record" "first" {
+  domain  = %[1]q
+  type    = "A"
+  name    = %[2]q
+  address = "203.0.113.60"
+}
+
+resource "spaceship_dns_record" "second" {
+  domain  = %[1]q
+  type    = "A"
+  name    = %[2]q
+  address = "203.0.113.60"
+}
+`, domain, recordName)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      config,
+				ExpectError: regexp.MustCompile(`Duplicate DNS Record Across Resources`),
+			},
+		},
+		CheckDestroy: testAccCheckDNSRecordAbsent(domain, "A", recordName),
+	})
+}
+
+// TestAccDNSRecord_strictCreateRefusesExisting verifies that with
+// adopt_existing = false a pre-existing identical record fails Create with an
+// import suggestion instead of being adopted.
+func TestAccDNSRecord_strictCreateRefusesExisting(t *testing.T) {
+	testAccPreCheck(t)
+
+	domain := testAccDomainValue()
+	recordName := testAccRecordPrefix() + "-strict"
+	record := client.DNSRecord{
+		Type:    "A",
+		Name:    recordName,
+		TTL:     3600,
+		Address: "203.0.113.70",
+	}
+
+	preCreate := func() {
+		c, err := testAccClient()
+		if err != nil {
+			t.Fatalf("failed to construct client: %s", err)
+		}
+		if err := c.CreateDNSRecord(context.Background(), domain, record); err != nil {
+			t.Fatalf("failed to pre-create DNS record: %s", err)
+		}
+	}
+
+	t.Cleanup(func() {
+		c, err := testAccClient()
+		if err != nil {
+			return
+		}
+		_ = c.DeleteDNSRecord(context.Background(), domain, record)
+	})
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: preCreate,
+				Config: fmt.Sprintf(`
+provider "spaceship" {}
+
+resource "spaceship_dns_record" "test" {
+  domain         = %q
+  type           = "A"
+  name           = %q
+  address        = %q
+  adopt_existing = false
+}
+`, domain, recordName, record.Address),
+				ExpectError: regexp.MustCompile(`(?s)DNS record already exists.*terraform import`),
+			},
+		},
+	})
+}
+
+// TestAccDNSRecord_importByIdentity verifies the structured resource identity:
+// it carries the per-type data fields, and an import block using it resolves
+// the same record as the composite ID.
+func TestAccDNSRecord_importByIdentity(t *testing.T) {
+	testAccPreCheck(t)
+
+	domain := testAccDomainValue()
+	recordName := testAccRecordPrefix() + "-identity"
+	resourceName := "spaceship_dns_record.test"
+
+	config := fmt.Sprintf(`
+provider "spaceship" {}
+
+resource "spaceship_dns_record" "test" {
+  domain     = %q
+  type       = "MX"
+  name       = %q
+  exchange   = "mail.example.com"
+  preference = 10
+}
+`, domain, recordName)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_12_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectIdentityValue(resourceName, tfjsonpath.New("domain"), knownvalue.StringExact(domain)),
+					statecheck.ExpectIdentityValue(resourceName, tfjsonpath.New("type"), knownvalue.StringExact("MX")),
+					statecheck.ExpectIdentityValue(resourceName, tfjsonpath.New("name"), knownvalue.StringExact(strings.ToLower(recordName))),
+					statecheck.ExpectIdentityValue(resourceName, tfjsonpath.New("exchange"), knownvalue.StringExact("mail.example.com")),
+					statecheck.ExpectIdentityValue(resourceName, tfjsonpath.New("preference"), knownvalue.Int64Exact(10)),
+					statecheck.ExpectIdentityValue(resourceName, tfjsonpath.New("address"), knownvalue.Null()),
+				},
+			},
+			{
+				ResourceName:    resourceName,
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithResourceIdentity,
+			},
+		},
+		CheckDestroy: testAccCheckDNSRecordAbsent(domain, "MX", recordName),
+	})
+}
+
+// TestAccDNSRecord_importShortIDAmbiguous verifies that a domain/TYPE/name
+// import matching several records fails and lists their full IDs.
+func TestAccDNSRecord_importShortIDAmbiguous(t *testing.T) {
+	testAccPreCheck(t)
+
+	domain := testAccDomainValue()
+	recordName := testAccRecordPrefix() + "-rr"
+
+	config := fmt.Sprintf(`
+provider "spaceship" {}
+
+resource "spaceship_dns_record" "first" {
+  domain  = %[1]q
+  type    = "A"
+  name    = %[2]q
+  address = "203.0.113.80"
+}
+
+resource "spaceship_dns_record" "second" {
+  domain  = %[1]q
+  type    = "A"
+  name    = %[2]q
+  address = "203.0.113.81"
+}
+`, domain, recordName)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+			},
+			{
+				ResourceName:  "spaceship_dns_record.first",
+				ImportState:   true,
+				ImportStateId: fmt.Sprintf("%s/A/%s", domain, recordName),
+				ExpectError:   regexp.MustCompile(`Multiple DNS records match`),
+			},
+		},
+		CheckDestroy: testAccCheckDNSRecordAbsent(domain, "A", recordName),
+	})
+}
@@ -1,7 +1,17 @@
 package provider
 
 import (
+	"context"
+	"strings"
 	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/namecheap/go-spaceship-sdk/client"
 )
 
 func TestParseRecordID(t *testing.T) {
@@ -61,3 +71,160 @@ func TestParseRecordID(t *testing.T) {
 		})
 	}
 }
+
+func TestRefuseExistingRecord(t *testing.T) {
+	c := newListClient(t, 0, []map[string]any{
+		{"type": "A", "name": "www", "ttl": 3600, "address": "192.0.2.1"},
+	})
+	r := &dnsRecordResource{client: c, records: newDNSRecordCache(c)}
+
+	existing := client.DNSRecord{Type: "A", Name: "WWW", TTL: 300, Address: "192.0.2.1"}
+	diags := r.refuseExistingRecord(context.Background(), "example.com", existing)
+	if diags.ErrorsCount() != 1 {
+		t.Fatalf("expected one error for an existing record, got %v", diags)
+	}
+	if detail := diags.Errors()[0].Detail(); !strings.Contains(detail, `terraform import <resource address> "example.com/A/www/192.0.2.1"`) {
+		t.Errorf("expected the import command in the detail, got %q", detail)
+	}
+
+	absent := client.DNSRecord{Type: "A", Name: "www", TTL: 3600, Address: "192.0.2.2"}
+	if diags := r.refuseExistingRecord(context.Background(), "example.com", absent); len(diags) != 0 {
+		t.Errorf("expected no diagnostics for a new record, got %v", diags)
+	}
+}
+
+func TestParseShortRecordID(t *testing.T) {
+	domain, recordType, name, ok := parseShortRecordID("example.com/HTTPS/@")
+	if !ok || domain != "example.com" || recordType != "HTTPS" || name != "@" {
+		t.Errorf("parseShortRecordID = %q, %q, %q, %v", domain, recordType, name, ok)
+	}
+	for _, id := range []string{"example.com/A", "example.com//www", "example.com/A/www/192.0.2.1"} {
+		if _, _, _, ok := parseShortRecordID(id); ok {
+			t.Errorf("parseShortRecordID(%q) accepted an invalid ID", id)
+		}
+	}
+}
+
+func TestResolveRecordID(t *testing.T) {
+	c := newListClient(t, 0, []map[string]any{
+		{"type": "HTTPS", "name": "@", "ttl": 3600, "svcPriority": 1, "targetName": ".", "svcParams": "alpn=h2"},
+		{"type": "A", "name": "www", "ttl": 3600, "address": "192.0.2.1"},
+		{"type": "A", "name": "www", "ttl": 3600, "address": "192.0.2.2"},
+	})
+	r := &dnsRecordResource{client: c, records: newDNSRecordCache(c)}
+	ctx := context.Background()
+
+	id, diags := r.resolveRecordID(ctx, "example.com", "https", "@")
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics for a single match: %v", diags)
+	}
+	if want := "example.com/HTTPS/@/1|.|alpn=h2||"; id != want {
+		t.Errorf("id = %q, want %q", id, want)
+	}
+
+	_, diags = r.resolveRecordID(ctx, "example.com", "A", "WWW")
+	if diags.ErrorsCount() != 1 {
+		t.Fatalf("expected one error for several matches, got %v", diags)
+	}
+	detail := diags.Errors()[0].Detail()
+	for _, want := range []string{"example.com/A/www/192.0.2.1", "example.com/A/www/192.0.2.2"} {
+		if !strings.Contains(detail, want) {
+			t.Errorf("expected candidate %q in the detail, got %q", want, detail)
+		}
+	}
+
+	_, diags = r.resolveRecordID(ctx, "example.com", "TXT", "www")
+	if diags.ErrorsCount() != 1 || diags.Errors()[0].Summary() != "DNS record not found" {
+		t.Errorf("expected a not-found error, got %v", diags)
+	}
+}
+
+// An identity built from a record imports back to the record's composite ID,
+// and case differences the API may echo back do not change the identity.
+func TestDNSRecordIdentity_RoundTrip(t *testing.T) {
+	ctx := context.Background()
+	r := &dnsRecordResource{}
+
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+	identitySchemaResp := &fwresource.IdentitySchemaResponse{}
+	r.IdentitySchema(ctx, fwresource.IdentitySchemaRequest{}, identitySchemaResp)
+
+	flag, pref, priority, weight, port := 0, 10, 5, 20, 5060
+	records := []client.DNSRecord{
+		{Type: "A", Name: "www", Address: "192.0.2.1"},
+		{Type: "MX", Name: "@", Exchange: "mail.example.com", Preference: &pref},
+		{Type: "TXT", Name: "@", Value: "v=spf1 Include:_spf.example.com -all"},
+		{Type: "CAA", Name: "@", Flag: &flag, Tag: "issue", Value: "letsencrypt.org"},
+		{Type: "SRV", Name: "@", Service: "_sip", Protocol: "_tcp", Priority: &priority, Weight: &weight, Port: client.NewIntPortValue(port), Target: "sip.example.com"},
+	}
+	for _, record := range records {
+		t.Run(record.Type, func(t *testing.T) {
+			value, diags := dnsRecordIdentity(ctx, "example.com", record)
+			if diags.HasError() {
+				t.Fatalf("dnsRecordIdentity: %v", diags)
+			}
+
+			echoed := record
+			echoed.Name = strings.ToUpper(echoed.Name)
+			echoed.TTL = 300
+			if echoed.Type != "TXT" {
+				echoed.Address = strings.ToUpper(echoed.Address)
+				echoed.Exchange = strings.ToUpper(echoed.Exchange)
+				echoed.Value = strings.ToUpper(echoed.Value)
+			}
+			echoedValue, diags := dnsRecordIdentity(ctx, "example.com", echoed)
+			if diags.HasError() {
+				t.Fatalf("dnsRecordIdentity: %v", diags)
+			}
+			if !value.Equal(echoedValue) {
+				t.Errorf("identity changed with case:\n%v\n%v", value, echoedValue)
+			}
+
+			identity := &tfsdk.ResourceIdentity{
+				Schema: identitySchemaResp.IdentitySchema,
+				Raw:    tftypes.NewValue(identitySchemaResp.IdentitySchema.Type().TerraformType(ctx), nil),
+			}
+			if diags := identity.Set(ctx, value); diags.HasError() {
+				t.Fatalf("identity.Set: %v", diags)
+			}
+			resp := &fwresource.ImportStateResponse{
+				State: tfsdk.State{
+					Schema: schemaResp.Schema,
+					Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+				},
+				Identity: identity,
+			}
+			r.ImportState(ctx, fwresource.ImportStateRequest{Identity: identity}, resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("ImportState: %v", resp.Diagnostics)
+			}
+
+			var id types.String
+			resp.State.GetAttribute(ctx, path.Root("id"), &id)
+			if want := recordID("example.com", record); id.ValueString() != want {
+				t.Errorf("id = %q, want %q", id.ValueString(), want)
+			}
+		})
+	}
+}
+
+func TestRecordIDFromIdentity_MissingDataField(t *testing.T) {
+	ctx := context.Background()
+	r := &dnsRecordResource{}
+	identitySchemaResp := &fwresource.IdentitySchemaResponse{}
+	r.IdentitySchema(ctx, fwresource.IdentitySchemaRequest{}, identitySchemaResp)
+
+	identity := &tfsdk.ResourceIdentity{
+		Schema: identitySchemaResp.IdentitySchema,
+		Raw:    tftypes.NewValue(identitySchemaResp.IdentitySchema.Type().TerraformType(ctx), nil),
+	}
+	identity.SetAttribute(ctx, path.Root("domain"), "example.com")
+	identity.SetAttribute(ctx, path.Root("type"), "MX")
+	identity.SetAttribute(ctx, path.Root("name"), "@")
+	identity.SetAttribute(ctx, path.Root("exchange"), "mail.example.com")
+
+	if _, diags := recordIDFromIdentity(ctx, identity); !diags.HasError() {
+		t.Error("expected an error for an MX identity without preference")
+	}
+}
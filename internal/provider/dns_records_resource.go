@@ -2,10 +2,13 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -15,11 +18,14 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"github.com/namecheap/go-spaceship-sdk/client"
+
+	"terraform-provider-spaceship/internal/provider/records"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
@@ -27,10 +33,12 @@ var (
 	_ resource.Resource                = &dnsRecordsResource{}
 	_ resource.ResourceWithConfigure   = &dnsRecordsResource{}
 	_ resource.ResourceWithImportState = &dnsRecordsResource{}
+	_ resource.ResourceWithIdentity    = &dnsRecordsResource{}
+	_ resource.ResourceWithModifyPlan  = &dnsRecordsResource{}
 )
 
 // Worst case create/update makes four rate-limitable calls (read, delete,
-// upsert, re-read) and delete makes two (clear = read + delete), each of
+// upsert, re-read) and delete makes two (read + delete), each of
 // which may wait out a full throttling window. Each default adds a minute of
 // slack so the last window's wait and the retried call still fit. See
 // internal/docs/rate-limits.md.
@@ -41,24 +49,42 @@ const (
 	dnsRecordsDeleteTimeout = 2*rateLimitWindow + time.Minute
 )
 
+// deletion_policy values. See permittedDeletions.
+const (
+	deletionPolicyAuthoritative = "authoritative"
+	deletionPolicyAdditive      = "additive"
+)
+
 func NewDNSRecordsResource() resource.Resource {
 	return &dnsRecordsResource{}
 }
 
 type dnsRecordsResource struct {
 	client *client.Client
+	// records is the shared per-domain read cache, also used by
+	// spaceship_dns_record. Every read goes through it and every write
+	// invalidates the domain; see listRecordsWithRetry.
+	records *dnsRecordCache
+	// claims rejects a record another resource also creates in this run.
+	claims *dnsRecordRegistry
 }
 
 type dnsRecordsResourceModel struct {
-	ID       types.String   `tfsdk:"id"`
-	Domain   types.String   `tfsdk:"domain"`
-	Force    types.Bool     `tfsdk:"force"`
-	Records  types.List     `tfsdk:"records"`
-	Timeouts timeouts.Value `tfsdk:"timeouts"`
+	ID             types.String   `tfsdk:"id"`
+	Domain         types.String   `tfsdk:"domain"`
+	Force          types.Bool     `tfsdk:"force"`
+	Records        types.List     `tfsdk:"records"`
+	ZoneFile       types.String   `tfsdk:"zone_file"`
+	DeletionPolicy types.String   `tfsdk:"deletion_policy"`
+	OwnedNames     types.Set      `tfsdk:"owned_names"`
+	Timeouts       timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (r *dnsRecordsResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_dns_records"
+	// A domain change is applied in place (see Update), so the identity
+	// legitimately changes without a replace.
+	resp.ResourceBehavior.MutableIdentity = true
 }
 
 func (r *dnsRecordsResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
@@ -95,7 +121,7 @@ func (r *dnsRecordsResource) Schema(ctx context.Context, _ resource.SchemaReques
 				},
 			},
 			"records": schema.ListNestedAttribute{
-				MarkdownDescription: "DNS records that should be configured for the domain. The provider diffs this list against existing custom records — only removed records are deleted and new or changed records are upserted. Records in other DNS groups (product, personalNS) are not affected.",
+				MarkdownDescription: "DNS records that should be configured for the domain. The provider diffs this list against existing custom records — only removed records are deleted and new or changed records are upserted. Records in other DNS groups (product, personalNS) are not affected. Conflicts with `zone_file`; when `zone_file` is set, this list is computed from it.",
 				Optional:            true,
 				Computed:            true,
 				PlanModifiers: []planmodifier.List{
@@ -109,6 +135,35 @@ func (r *dnsRecordsResource) Schema(ctx context.Context, _ resource.SchemaReques
 					Attributes: recordAttributes(),
 				},
 			},
+			"deletion_policy": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "How the resource treats live custom records it does not declare. `authoritative` (default) owns the whole custom group: undeclared records are deleted on apply and destroy clears the group. `additive` never deletes records it did not create: records removed from `records` are deleted only if this resource created them, undeclared records are left alone and not tracked in state, and destroy removes only the records this resource created. Declaring a record that already exists adopts it into state without taking ownership, so it is never deleted. Use `additive` to share a domain with `spaceship_dns_record` or with records managed by hand.",
+				Default:             stringdefault.StaticString(deletionPolicyAuthoritative),
+				Validators: []validator.String{
+					stringvalidator.OneOf(deletionPolicyAuthoritative, deletionPolicyAdditive),
+				},
+			},
+			"owned_names": schema.SetAttribute{
+				MarkdownDescription: "Restricts the resource to the part of the zone under these names. A record is in scope when its `name` equals an entry or is a subdomain of it — `dev` covers `dev`, `api.dev` and `*.dev` — and `@` covers only records at the apex. Records outside the scope are never read into state, diffed, or deleted, so several instances can manage disjoint parts of one domain. Every declared record must lie inside the scope. When unset the resource manages the whole custom group.",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(
+						stringvalidator.LengthBetween(1, 253),
+						records.NameValidator(),
+					),
+				},
+			},
+			"zone_file": schema.StringAttribute{
+				MarkdownDescription: "DNS records for the domain as an RFC 1035 (BIND) zone file, as an alternative to `records` — typically `file(\"example.com.zone\")` when migrating from another DNS host. `$ORIGIN` and `$TTL` are honored and owner names must lie within `domain`; `SOA` records are ignored. Record types the Spaceship API does not support, `$INCLUDE`, and invalid or duplicate records are reported with their line number at plan time. Conflicts with `records`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("records")),
+					zoneFileValidator{},
+				},
+			},
 		},
 		Blocks: map[string]schema.Block{
 			"timeouts": timeouts.Block(ctx, timeouts.Opts{
@@ -132,6 +187,8 @@ func (r *dnsRecordsResource) Configure(_ context.Context, req resource.Configure
 		return
 	}
 	r.client = pd.Client
+	r.records = pd.DNSRecords
+	r.claims = pd.DNSRecordClaims
 }
 
 func (r *dnsRecordsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -159,31 +216,52 @@ func (r *dnsRecordsResource) Create(ctx context.Context, req resource.CreateRequ
 		return
 	}
 
-	existingRecords, err := getDNSRecordsWithRetry(ctx, r.client, plan.Domain.ValueString())
+	// ModifyPlan skips records whose name was unknown at plan time, so the
+	// scope is checked again here before anything is written.
+	scope, diags := ownedNamesScope(ctx, plan.OwnedNames)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(validateRecordsInScope(desiredRecords, scope, plan.ZoneFile.IsNull())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(r.claims.Claim(plan.Domain.ValueString(), "spaceship_dns_records for "+plan.Domain.ValueString(), desiredRecords)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	existingRecords, err := r.listRecordsWithRetry(ctx, plan.Domain.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Spaceship API error", fmt.Sprintf("failed to read existing DNS records: %s", err))
 		return
 	}
 
-	toDelete, toUpsert := diffDNSRecords(existingRecords, desiredRecords)
-	if err := deleteDNSRecordsWithRetry(ctx, r.client, plan.Domain.ValueString(), toDelete); err != nil {
+	toDelete, toUpsert := diffDNSRecords(scopeRecords(existingRecords, scope), desiredRecords)
+	toDelete = permittedDeletions(plan.DeletionPolicy, types.StringNull(), nil, toDelete)
+	var owned []client.DNSRecord
+	if isAdditive(plan.DeletionPolicy) {
+		owned = createdRecords(nil, existingRecords, desiredRecords, toUpsert)
+	}
+	if err := r.deleteRecordsWithRetry(ctx, plan.Domain.ValueString(), toDelete); err != nil {
 		resp.Diagnostics.AddError("Spaceship API error", fmt.Sprintf("Failed to delete DNS records: %s", err))
 		return
 	}
 
-	if len(toUpsert) > 0 {
-		if err := upsertDNSRecordsWithRetry(ctx, r.client, plan.Domain.ValueString(), force, toUpsert); err != nil {
-			resp.Diagnostics.AddError("Spaceship API error", fmt.Sprintf("Failed to apply DNS records: %s", err))
-			return
-		}
+	if err := r.upsertRecordsWithRetry(ctx, plan.Domain.ValueString(), force, toUpsert); err != nil {
+		resp.Diagnostics.AddError("Spaceship API error", fmt.Sprintf("Failed to apply DNS records: %s", err))
+		return
 	}
 
-	updatedRecords, err := getDNSRecordsWithRetry(ctx, r.client, plan.Domain.ValueString())
+	// A no-op apply invalidated nothing, so this re-read is a cache hit.
+	updatedRecords, err := r.listRecordsWithRetry(ctx, plan.Domain.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Spaceship API error", fmt.Sprintf("Failed to refresh DNS records: %s", err))
 		return
 	}
 
+	updatedRecords = scopeRecords(updatedRecords, scope)
+	if isAdditive(plan.DeletionPolicy) {
+		updatedRecords = filterOwnedRecords(updatedRecords, desiredRecords)
+	}
 	orderedRecords := orderDNSRecordsLike(desiredRecords, updatedRecords)
 
 	flattened, diags := flattenDNSRecords(ctx, orderedRecords)
@@ -197,6 +275,8 @@ func (r *dnsRecordsResource) Create(ctx context.Context, req resource.CreateRequ
 	plan.Records = flattened
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, domainIdentityModel{Domain: plan.Domain})...)
+	resp.Diagnostics.Append(setOwnedRecords(ctx, resp.Private, owned)...)
 }
 
 func (r *dnsRecordsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -223,7 +303,15 @@ func (r *dnsRecordsResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
-	apiRecords, err := getDNSRecordsWithRetry(ctx, r.client, state.Domain.ValueString())
+	scope, diags := ownedNamesScope(ctx, state.OwnedNames)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.claimZone(state.Domain.ValueString(), state.DeletionPolicy, scope)...)
+
+	apiRecords, err := r.listRecordsWithRetry(ctx, state.Domain.ValueString())
 	if err != nil {
 		if client.IsNotFoundError(err) {
 			resp.State.RemoveResource(ctx)
@@ -234,7 +322,15 @@ func (r *dnsRecordsResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
+	// Records outside owned_names, and in additive mode records this resource
+	// does not declare, belong to someone else and must not show up as drift.
+	apiRecords = scopeRecords(apiRecords, scope)
+	if isAdditive(state.DeletionPolicy) {
+		apiRecords = filterOwnedRecords(apiRecords, stateRecords)
+	}
 	orderedRecords := orderDNSRecordsLike(stateRecords, apiRecords)
+	// Lets ModifyPlan reject a new resource that declares one of these too.
+	r.claims.Record(state.Domain.ValueString(), state.ID.ValueString(), "spaceship_dns_records for "+state.Domain.ValueString(), orderedRecords)
 
 	flattenedRecords, diags := flattenDNSRecords(ctx, orderedRecords)
 	resp.Diagnostics.Append(diags...)
@@ -243,8 +339,14 @@ func (r *dnsRecordsResource) Read(ctx context.Context, req resource.ReadRequest,
 	}
 
 	state.Records = flattenedRecords
+	// State written before deletion_policy existed has it null; backfill the
+	// default here so upgrading the provider does not plan a spurious update.
+	if state.DeletionPolicy.IsNull() {
+		state.DeletionPolicy = types.StringValue(deletionPolicyAuthoritative)
+	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, domainIdentityModel{Domain: state.Domain})...)
 }
 
 func (r *dnsRecordsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -253,8 +355,9 @@ func (r *dnsRecordsResource) Update(ctx context.Context, req resource.UpdateRequ
 		return
 	}
 
-	var plan dnsRecordsResourceModel
+	var plan, state dnsRecordsResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -272,32 +375,61 @@ func (r *dnsRecordsResource) Update(ctx context.Context, req resource.UpdateRequ
 		return
 	}
 
-	existingRecords, err := getDNSRecordsWithRetry(ctx, r.client, plan.Domain.ValueString())
+	// ModifyPlan skips records whose name was unknown at plan time, so the
+	// scope is checked again here before anything is written.
+	scope, diags := ownedNamesScope(ctx, plan.OwnedNames)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(validateRecordsInScope(desiredRecords, scope, plan.ZoneFile.IsNull())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(r.claims.Claim(plan.Domain.ValueString(), "spaceship_dns_records for "+plan.Domain.ValueString(), desiredRecords)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	existingRecords, err := r.listRecordsWithRetry(ctx, plan.Domain.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Spaceship API error", fmt.Sprintf("failed to read existing DNS Records: %s", err))
 		return
 	}
 
-	toDelete, toUpsert := diffDNSRecords(existingRecords, desiredRecords)
+	priorOwned, diags := getOwnedRecords(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	toDelete, toUpsert := diffDNSRecords(scopeRecords(existingRecords, scope), desiredRecords)
+	toDelete = permittedDeletions(plan.DeletionPolicy, state.DeletionPolicy, priorOwned, toDelete)
+	var owned []client.DNSRecord
+	if isAdditive(plan.DeletionPolicy) {
+		if !isAdditive(state.DeletionPolicy) {
+			priorOwned = nil
+		}
+		owned = createdRecords(priorOwned, existingRecords, desiredRecords, toUpsert)
+	}
 
-	if err := deleteDNSRecordsWithRetry(ctx, r.client, plan.Domain.ValueString(), toDelete); err != nil {
+	if err := r.deleteRecordsWithRetry(ctx, plan.Domain.ValueString(), toDelete); err != nil {
 		resp.Diagnostics.AddError("Spaceship API error", fmt.Sprintf("Failed to delete DNS records: %s", err))
 		return
 	}
 
-	if len(toUpsert) > 0 {
-		if err := upsertDNSRecordsWithRetry(ctx, r.client, plan.Domain.ValueString(), force, toUpsert); err != nil {
-			resp.Diagnostics.AddError("Spaceship API error", fmt.Sprintf("Failed to update DNS records: %s", err))
-			return
-		}
+	if err := r.upsertRecordsWithRetry(ctx, plan.Domain.ValueString(), force, toUpsert); err != nil {
+		resp.Diagnostics.AddError("Spaceship API error", fmt.Sprintf("Failed to update DNS records: %s", err))
+		return
 	}
 
-	updatedRecords, err := getDNSRecordsWithRetry(ctx, r.client, plan.Domain.ValueString())
+	updatedRecords, err := r.listRecordsWithRetry(ctx, plan.Domain.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Spaceship API error", fmt.Sprintf("Failed to refresh DNS records: %s", err))
 		return
 	}
 
+	updatedRecords = scopeRecords(updatedRecords, scope)
+	if isAdditive(plan.DeletionPolicy) {
+		updatedRecords = filterOwnedRecords(updatedRecords, desiredRecords)
+	}
 	orderedRecords := orderDNSRecordsLike(desiredRecords, updatedRecords)
 
 	flattened, diags := flattenDNSRecords(ctx, orderedRecords)
@@ -310,6 +442,8 @@ func (r *dnsRecordsResource) Update(ctx context.Context, req resource.UpdateRequ
 	plan.Force = types.BoolValue(force)
 	plan.Records = flattened
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, domainIdentityModel{Domain: plan.Domain})...)
+	resp.Diagnostics.Append(setOwnedRecords(ctx, resp.Private, owned)...)
 }
 
 func (r *dnsRecordsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -330,7 +464,31 @@ func (r *dnsRecordsResource) Delete(ctx context.Context, req resource.DeleteRequ
 		return
 	}
 
-	if err := clearDNSRecordsWithRetry(ctx, r.client, state.Domain.ValueString()); err != nil {
+	scope, diags := ownedNamesScope(ctx, state.OwnedNames)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	owned, diags := getOwnedRecords(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	domain := state.Domain.ValueString()
+	live, err := r.listRecordsWithRetry(ctx, domain)
+	if err != nil && !client.IsNotFoundError(err) {
+		resp.Diagnostics.AddError("Spaceship API error", fmt.Sprintf("Failed to read DNS records: %s", err))
+		return
+	}
+
+	// Without owned_names or additive ownership this clears the custom group.
+	live = scopeRecords(live, scope)
+	if isAdditive(state.DeletionPolicy) {
+		live = filterOwnedRecords(live, owned)
+	}
+	if err := r.deleteRecordsWithRetry(ctx, domain, live); err != nil {
 		resp.Diagnostics.AddError("Spaceship API error", fmt.Sprintf("Failed to clear DNS records: %s", err))
 		return
 	}
@@ -340,9 +498,219 @@ func (r *dnsRecordsResource) Delete(ctx context.Context, req resource.DeleteRequ
 
 func (r *dnsRecordsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resourceID := req.ID
+	if resourceID == "" {
+		// Import by identity; the resource ID is the domain name.
+		var identity domainIdentityModel
+		resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		resourceID = identity.Domain.ValueString()
+	}
 
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), resourceID)...)
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("domain"), resourceID)...)
+	// An import reads every live record into state, so it is authoritative
+	// by construction; see permittedDeletions.
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("deletion_policy"), deletionPolicyAuthoritative)...)
+}
+
+func (r *dnsRecordsResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = domainIdentitySchema()
+}
+
+// ModifyPlan expands zone_file into the planned records list, so the plan shows
+// the individual records and Create/Update reconcile them exactly like a
+// configured list. Whenever the apply will touch the zone it then previews the
+// change against the live records; see previewRecordChanges.
+func (r *dnsRecordsResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		var state dnsRecordsResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if r.claims != nil {
+			r.claims.Release(state.ID.ValueString())
+		}
+		owned, diags := getOwnedRecords(ctx, req.Private)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		scope, diags := ownedNamesScope(ctx, state.OwnedNames)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		permit := func(toDelete []client.DNSRecord) []client.DNSRecord {
+			return permittedDeletions(state.DeletionPolicy, state.DeletionPolicy, owned, scopeRecords(toDelete, scope))
+		}
+		resp.Diagnostics.Append(r.previewRecordChanges(ctx, state.Domain.ValueString(), state.Timeouts.Read, nil, permit)...)
+		return
+	}
+
+	var plan dnsRecordsResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !plan.ZoneFile.IsNull() {
+		if plan.ZoneFile.IsUnknown() || plan.Domain.IsUnknown() {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("records"), types.ListUnknown(dnsRecordObjectType))...)
+			return
+		}
+
+		// zoneFileValidator reports only the errors, so the per-record
+		// warnings appear once, from here.
+		records, diags := zoneFileToDNSRecords(ctx, plan.ZoneFile.ValueString(), plan.Domain.ValueString(), path.Root("zone_file"))
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		flattened, diags := flattenDNSRecords(ctx, records)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("records"), flattened)...)
+		plan.Records = flattened
+	}
+
+	if !plan.Domain.IsUnknown() && !plan.DeletionPolicy.IsUnknown() && !plan.OwnedNames.IsUnknown() {
+		scope, diags := ownedNamesScope(ctx, plan.OwnedNames)
+		resp.Diagnostics.Append(diags...)
+		resp.Diagnostics.Append(r.claimZone(plan.Domain.ValueString(), plan.DeletionPolicy, scope)...)
+	}
+
+	// The scope check needs only record names, so unlike the preview below it
+	// runs while other record attributes are still unknown.
+	if !plan.Records.IsUnknown() && !plan.OwnedNames.IsUnknown() {
+		scope, diags := ownedNamesScope(ctx, plan.OwnedNames)
+		resp.Diagnostics.Append(diags...)
+		resp.Diagnostics.Append(validateRecordsInScope(plannedRecordNames(plan.Records), scope, plan.ZoneFile.IsNull())...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// An unchanged plan reconciles nothing the refresh has not already shown.
+	// With unknowns the desired set is not final, so a preview would mislead.
+	if !req.State.Raw.IsNull() && resp.Plan.Raw.Equal(req.State.Raw) {
+		return
+	}
+	if plan.Domain.IsUnknown() || plan.Records.IsUnknown() || plan.Records.IsNull() {
+		return
+	}
+	for _, element := range plan.Records.Elements() {
+		if object, ok := element.(types.Object); !ok || object.IsUnknown() || recordHasUnknownAttribute(object) {
+			return
+		}
+	}
+
+	desired, diags := expandDNSRecords(ctx, plan.Records, path.Root("records"))
+	if diags.HasError() {
+		// The records validators report these; the preview just stays quiet.
+		return
+	}
+
+	// A planned create must not duplicate a record another resource holds in
+	// state; see dnsRecordRegistry.
+	if req.State.Raw.IsNull() && r.claims != nil {
+		resp.Diagnostics.Append(r.claims.Check(plan.Domain.ValueString(), "spaceship_dns_records for "+plan.Domain.ValueString(), desired)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	if plan.OwnedNames.IsUnknown() {
+		return
+	}
+	scope, diags := ownedNamesScope(ctx, plan.OwnedNames)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// On Create the state is null, so state.DeletionPolicy is null and
+	// permittedDeletions treats the zone as carrying no ownership information.
+	var state dnsRecordsResourceModel
+	var owned []client.DNSRecord
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		owned, diags = getOwnedRecords(ctx, req.Private)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	permit := func(toDelete []client.DNSRecord) []client.DNSRecord {
+		return permittedDeletions(plan.DeletionPolicy, state.DeletionPolicy, owned, scopeRecords(toDelete, scope))
+	}
+	resp.Diagnostics.Append(r.previewRecordChanges(ctx, plan.Domain.ValueString(), plan.Timeouts.Read, desired, permit)...)
+}
+
+// previewRecordChanges reads the live zone (a cache hit after the refresh) and
+// warns with every record the apply will delete or upsert, in zone-file
+// notation. records cannot show this itself: it is Computed with
+// UseStateForUnknown, so records created outside Terraform since the last
+// refresh are absent from the plan diff yet still deleted. desired is nil for
+// a destroy, which clears the custom group. permit narrows the deletions to
+// those owned_names and deletion_policy allow; nil keeps all. A failed read
+// only downgrades to a warning — the preview is advisory and must not block a
+// plan the apply would retry anyway.
+func (r *dnsRecordsResource) previewRecordChanges(ctx context.Context, domain string, readTimeout func(context.Context, time.Duration) (time.Duration, diag.Diagnostics), desired []client.DNSRecord, permit func([]client.DNSRecord) []client.DNSRecord) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if r.client == nil || domain == "" {
+		return diags
+	}
+
+	ctx, cancel := operationContext(ctx, readTimeout, dnsRecordsReadTimeout, &diags)
+	defer cancel()
+	if diags.HasError() {
+		return diags
+	}
+
+	existing, err := r.listRecordsWithRetry(ctx, domain)
+	if err != nil {
+		if !client.IsNotFoundError(err) {
+			diags.AddWarning("Unable to preview DNS record changes", fmt.Sprintf("Reading the live DNS records of %s failed, so this plan cannot list the records the apply will delete or upsert: %s", domain, err))
+		}
+		return diags
+	}
+
+	toDelete, toUpsert := diffDNSRecords(existing, desired)
+	if permit != nil {
+		toDelete = permit(toDelete)
+	}
+	if len(toDelete) == 0 && len(toUpsert) == 0 {
+		return diags
+	}
+	diags.AddWarning("DNS records will change", describeRecordChanges(domain, toDelete, toUpsert))
+	return diags
+}
+
+// describeRecordChanges lists deletions ("-") and upserts ("+") one per line,
+// in the same notation as the zone file export.
+func describeRecordChanges(domain string, toDelete, toUpsert []client.DNSRecord) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Applying this plan deletes (-) or upserts (+) these custom records of %s. The list is computed against the live zone, so it includes records created outside Terraform:\n", domain)
+	for _, record := range toDelete {
+		fmt.Fprintf(&b, "\n  - %s", describeRecord(record))
+	}
+	for _, record := range toUpsert {
+		fmt.Fprintf(&b, "\n  + %s", describeRecord(record))
+	}
+	return b.String()
+}
+
+func describeRecord(record client.DNSRecord) string {
+	return fmt.Sprintf("%s %d %s %s", zoneOwner(record), record.TTL, strings.ToUpper(record.Type), zoneRData(record))
 }
 
 func expandDNSRecords(ctx context.Context, list types.List, listPath path.Path) ([]client.DNSRecord, diag.Diagnostics) {
@@ -461,6 +829,247 @@ func orderDNSRecordsLike(reference, records []client.DNSRecord) []client.DNSReco
 	return ordered
 }
 
+// permittedDeletions narrows the deletions diffDNSRecords computed to what
+// deletion_policy allows. Authoritative deletes them all. Additive deletes only
+// records the resource created, as recorded by a prior additive apply (see
+// createdRecords). That includes records since removed from records, which
+// are deleted rather than left live and untracked. An authoritative or
+// imported state records no ownership, so switching to additive deletes
+// nothing on its first apply.
+func permittedDeletions(policy, priorPolicy types.String, owned, toDelete []client.DNSRecord) []client.DNSRecord {
+	if !isAdditive(policy) {
+		return toDelete
+	}
+	if !isAdditive(priorPolicy) {
+		return nil
+	}
+	return filterOwnedRecords(toDelete, owned)
+}
+
+// createdRecords returns the desired records this resource owns after a write:
+// those it owned before plus the upserts that did not exist in the zone yet. A
+// declared record that was already live is adopted into state but never
+// becomes owned, so additive mode never deletes it. Owned records no longer
+// desired have just been deleted and drop out.
+func createdRecords(owned, existing, desired, toUpsert []client.DNSRecord) []client.DNSRecord {
+	live := make(map[string]struct{}, len(existing))
+	for _, record := range existing {
+		live[client.RecordKey(record)] = struct{}{}
+	}
+
+	created := append([]client.DNSRecord(nil), owned...)
+	for _, record := range toUpsert {
+		if _, ok := live[client.RecordKey(record)]; !ok {
+			created = append(created, record)
+		}
+	}
+	return filterOwnedRecords(desired, created)
+}
+
+// ownedRecordsKey is the private state key holding the records an additive
+// instance created. They cannot live in records, which must match the plan
+// and so also lists adopted records; Terraform keeps private state alongside
+// the resource without showing it in plans.
+const ownedRecordsKey = "owned_records"
+
+type privateStateReader interface {
+	GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics)
+}
+
+type privateStateWriter interface {
+	SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics
+}
+
+// getOwnedRecords reads ownedRecordsKey; a missing key means nothing is owned.
+func getOwnedRecords(ctx context.Context, private privateStateReader) ([]client.DNSRecord, diag.Diagnostics) {
+	raw, diags := private.GetKey(ctx, ownedRecordsKey)
+	if diags.HasError() || len(raw) == 0 {
+		return nil, diags
+	}
+
+	var owned []client.DNSRecord
+	if err := json.Unmarshal(raw, &owned); err != nil {
+		diags.AddError("Invalid private state", fmt.Sprintf("Failed to decode the records this resource created: %s", err))
+		return nil, diags
+	}
+	return owned, diags
+}
+
+// setOwnedRecords writes ownedRecordsKey, removing it when nothing is owned.
+func setOwnedRecords(ctx context.Context, private privateStateWriter, owned []client.DNSRecord) diag.Diagnostics {
+	if len(owned) == 0 {
+		return private.SetKey(ctx, ownedRecordsKey, nil)
+	}
+
+	raw, err := json.Marshal(owned)
+	if err != nil {
+		var diags diag.Diagnostics
+		diags.AddError("Invalid private state", fmt.Sprintf("Failed to encode the records this resource created: %s", err))
+		return diags
+	}
+	return private.SetKey(ctx, ownedRecordsKey, raw)
+}
+
+func isAdditive(policy types.String) bool {
+	return policy.ValueString() == deletionPolicyAdditive
+}
+
+// filterOwnedRecords keeps the records whose API identity (client.RecordKey)
+// matches one of owned. TTL is not part of the identity, so a TTL edited
+// outside Terraform still surfaces as drift on an owned record.
+func filterOwnedRecords(records, owned []client.DNSRecord) []client.DNSRecord {
+	keys := make(map[string]struct{}, len(owned))
+	for _, record := range owned {
+		keys[client.RecordKey(record)] = struct{}{}
+	}
+
+	var filtered []client.DNSRecord
+	for _, record := range records {
+		if _, ok := keys[client.RecordKey(record)]; ok {
+			filtered = append(filtered, record)
+		}
+	}
+	return filtered
+}
+
+// listRecordsWithRetry reads the domain's custom records through the shared
+// cache, so a plan's refresh and preview, and every resource on the domain,
+// share one fetch. As in findRecordWithRetry, retry wraps the cache lookup.
+func (r *dnsRecordsResource) listRecordsWithRetry(ctx context.Context, domain string) ([]client.DNSRecord, error) {
+	return withRetryValue(ctx, "read DNS records", domain, func() ([]client.DNSRecord, error) {
+		return r.records.List(ctx, domain)
+	})
+}
+
+// deleteRecordsWithRetry and upsertRecordsWithRetry skip empty writes and
+// invalidate the domain after a successful one, so a no-op apply keeps the
+// cache warm and a real one is never followed by a stale read.
+func (r *dnsRecordsResource) deleteRecordsWithRetry(ctx context.Context, domain string, records []client.DNSRecord) error {
+	if len(records) == 0 {
+		return nil
+	}
+	err := deleteDNSRecordsWithRetry(ctx, r.client, domain, records)
+	if err == nil {
+		r.records.Invalidate(domain)
+	}
+	return err
+}
+
+func (r *dnsRecordsResource) upsertRecordsWithRetry(ctx context.Context, domain string, force bool, records []client.DNSRecord) error {
+	if len(records) == 0 {
+		return nil
+	}
+	err := upsertDNSRecordsWithRetry(ctx, r.client, domain, force, records)
+	if err == nil {
+		r.records.Invalidate(domain)
+	}
+	return err
+}
+
+// claimZone registers an authoritative instance with the cache's overlap
+// detection; additive instances leave foreign records alone and never claim.
+func (r *dnsRecordsResource) claimZone(domain string, policy types.String, scope []string) diag.Diagnostics {
+	if r.records == nil || isAdditive(policy) {
+		return nil
+	}
+	return r.records.ClaimZone(domain, scope)
+}
+
+// ownedNamesScope returns the owned_names entries lower-cased and without a
+// trailing dot, or nil when owned_names is unset (the whole custom group).
+func ownedNamesScope(ctx context.Context, ownedNames types.Set) ([]string, diag.Diagnostics) {
+	if ownedNames.IsNull() || ownedNames.IsUnknown() {
+		return nil, nil
+	}
+
+	var names []string
+	diags := ownedNames.ElementsAs(ctx, &names, false)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	scope := make([]string, len(names))
+	for i, name := range names {
+		scope[i] = strings.TrimSuffix(strings.ToLower(name), ".")
+	}
+	return scope, diags
+}
+
+// nameInScope reports whether a record name equals a scope entry or is a
+// subdomain of one. "@" only matches the apex: every name is below the apex,
+// so treating it as a suffix would make the scope meaningless.
+func nameInScope(name string, scope []string) bool {
+	name = strings.ToLower(name)
+	for _, entry := range scope {
+		if name == entry || (entry != "@" && strings.HasSuffix(name, "."+entry)) {
+			return true
+		}
+	}
+	return false
+}
+
+// scopeRecords keeps the records inside scope; a nil scope keeps them all.
+func scopeRecords(records []client.DNSRecord, scope []string) []client.DNSRecord {
+	if scope == nil {
+		return records
+	}
+
+	var scoped []client.DNSRecord
+	for _, record := range records {
+		if nameInScope(record.Name, scope) {
+			scoped = append(scoped, record)
+		}
+	}
+	return scoped
+}
+
+// validateRecordsInScope rejects declared records outside owned_names: they
+// would be upserted but never read back, so every plan would show them as
+// missing. fromList selects whether errors point at records[i].name or, for
+// records expanded from zone_file, at zone_file itself. An empty name is still
+// unknown (see plannedRecordNames) and is skipped.
+func validateRecordsInScope(desired []client.DNSRecord, scope []string, fromList bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if scope == nil {
+		return diags
+	}
+
+	for i, record := range desired {
+		if record.Name == "" || nameInScope(record.Name, scope) {
+			continue
+		}
+		attrPath := path.Root("zone_file")
+		if fromList {
+			attrPath = path.Root("records").AtListIndex(i).AtName("name")
+		}
+		diags.AddAttributeError(attrPath, "Record Outside owned_names",
+			fmt.Sprintf("The %s record %q is not covered by owned_names (%s). Add its name to owned_names or remove the record.", record.Type, record.Name, strings.Join(scope, ", ")))
+	}
+	return diags
+}
+
+// plannedRecordNames returns the type and name of every planned record, in
+// list order, leaving a field empty while it is unknown. Unlike
+// expandDNSRecords it works on a plan whose other attributes are not known yet.
+func plannedRecordNames(list types.List) []client.DNSRecord {
+	elements := list.Elements()
+	records := make([]client.DNSRecord, len(elements))
+	for i, element := range elements {
+		object, ok := element.(types.Object)
+		if !ok || object.IsUnknown() || object.IsNull() {
+			continue
+		}
+		attributes := object.Attributes()
+		if value, ok := attributes["type"].(types.String); ok {
+			records[i].Type = value.ValueString()
+		}
+		if value, ok := attributes["name"].(types.String); ok {
+			records[i].Name = value.ValueString()
+		}
+	}
+	return records
+}
+
 func boolOrDefault(value types.Bool, fallback bool) bool {
 	if value.IsNull() || value.IsUnknown() {
 		return fallback
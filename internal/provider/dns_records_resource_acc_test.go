@@ -578,7 +578,272 @@ type testAccDNSRecord struct {
 	IntAttrs    map[string]int
 }
 
+func TestAccDNSRecords_zoneFile(t *testing.T) {
+	testAccPreCheck(t)
+
+	domain := testAccDomainValue()
+	host := fmt.Sprintf("%s-zone", testAccRecordPrefix())
+	resourceName := "spaceship_dns_records.test"
+
+	zone := fmt.Sprintf(`$ORIGIN %[1]s.
+$TTL 600
+%[2]s  IN  A    198.51.100.40
+           TXT  ( "zone file "
+                  "import" )
+_sip._tcp.%[2]s  SRV  10 5 5060 sip.%[1]s.
+`, domain, host)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDNSRecordsZoneFileConfig(domain, zone),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "records.#", "3"),
+					resource.TestCheckResourceAttr(resourceName, "records.0.type", "A"),
+					resource.TestCheckResourceAttr(resourceName, "records.0.name", host),
+					resource.TestCheckResourceAttr(resourceName, "records.0.ttl", "600"),
+					resource.TestCheckResourceAttr(resourceName, "records.1.value", "zone file import"),
+					resource.TestCheckResourceAttr(resourceName, "records.2.service", "_sip"),
+					resource.TestCheckResourceAttr(resourceName, "records.2.name", host),
+					resource.TestCheckResourceAttr(resourceName, "records.2.port_number", "5060"),
+				),
+			},
+			{
+				Config: testAccDNSRecordsZoneFileConfig(domain, zone),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+		},
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testAccCheckDNSRecordAbsent(domain, "A", host),
+			testAccCheckDNSRecordAbsent(domain, "TXT", host),
+		),
+	})
+}
+
+func TestAccDNSRecords_zoneFileUnsupportedTypeFailsPlan(t *testing.T) {
+	testAccPreCheck(t)
+
+	domain := testAccDomainValue()
+	zone := "www  A      198.51.100.41\nwww  SSHFP  1 1 123456789abcdef67890123456789abcdef67890\n"
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccDNSRecordsZoneFileConfig(domain, zone),
+				ExpectError: regexp.MustCompile(`(?s)Line 2:.*SSHFP`),
+			},
+		},
+	})
+}
+
+// TestAccDNSRecords_additiveLeavesUnmanagedRecords verifies that with
+// deletion_policy = "additive" a record created outside Terraform survives
+// Create, Update, and Delete, while a record the resource wrote itself is
+// still removed once it leaves the configuration.
+func TestAccDNSRecords_additiveLeavesUnmanagedRecords(t *testing.T) {
+	testAccPreCheck(t)
+
+	domain := testAccDomainValue()
+	prefix := testAccRecordPrefix()
+	resourceName := "spaceship_dns_records.test"
+	firstName := fmt.Sprintf("%s-additive-1", prefix)
+	secondName := fmt.Sprintf("%s-additive-2", prefix)
+	unmanagedName := fmt.Sprintf("%s-unmanaged", prefix)
+
+	unmanagedRecord := client.DNSRecord{
+		Type:  "TXT",
+		Name:  unmanagedName,
+		TTL:   3600,
+		Value: "created outside terraform",
+	}
+
+	preConfig := func() {
+		testClient, err := testAccClient()
+		if err != nil {
+			t.Fatalf("failed to create test client: %v", err)
+		}
+		if err := testClient.UpsertDNSRecords(context.Background(), domain, true, []client.DNSRecord{unmanagedRecord}); err != nil {
+			t.Fatalf("failed to pre-seed record: %v", err)
+		}
+	}
+
+	t.Cleanup(func() {
+		testClient, err := testAccClient()
+		if err != nil {
+			return
+		}
+		_ = testClient.DeleteDNSRecords(context.Background(), domain, []client.DNSRecord{unmanagedRecord})
+	})
+
+	aRecord := func(name, address string) testAccDNSRecord {
+		return testAccDNSRecord{
+			Type:        "A",
+			Name:        name,
+			TTL:         intPtr(3600),
+			StringAttrs: map[string]string{"address": address},
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: preConfig,
+				Config:    testAccDNSRecordsPolicyConfig(domain, "additive", []testAccDNSRecord{aRecord(firstName, "198.51.100.61")}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "deletion_policy", "additive"),
+					resource.TestCheckResourceAttr(resourceName, "records.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "records.0.name", firstName),
+					testAccCheckDNSRecordPresent(domain, "TXT", unmanagedName),
+				),
+			},
+			{
+				Config: testAccDNSRecordsPolicyConfig(domain, "additive", []testAccDNSRecord{aRecord(firstName, "198.51.100.61")}),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+			{
+				// The first record was written by this resource, so dropping
+				// it from the configuration still deletes it.
+				Config: testAccDNSRecordsPolicyConfig(domain, "additive", []testAccDNSRecord{aRecord(secondName, "198.51.100.62")}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "records.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "records.0.name", secondName),
+					testAccCheckDNSRecordAbsent(domain, "A", firstName),
+					testAccCheckDNSRecordPresent(domain, "TXT", unmanagedName),
+				),
+			},
+		},
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testAccCheckDNSRecordAbsent(domain, "A", secondName),
+			testAccCheckDNSRecordPresent(domain, "TXT", unmanagedName),
+		),
+	})
+}
+
+// TestAccDNSRecords_ownedNamesSplitZone verifies that two instances scoped
+// to disjoint owned_names manage one domain side by side: neither reads the
+// other's records into state or deletes them.
+func TestAccDNSRecords_ownedNamesSplitZone(t *testing.T) {
+	testAccPreCheck(t)
+
+	domain := testAccDomainValue()
+	prefix := testAccRecordPrefix()
+	teamA := fmt.Sprintf("%s-team-a", prefix)
+	teamB := fmt.Sprintf("%s-team-b", prefix)
+
+	config := fmt.Sprintf(`
+provider "spaceship" {}
+
+resource "spaceship_dns_records" "team_a" {
+  domain      = %[1]q
+  owned_names = [%[2]q]
+
+  records = [
+    {
+      type    = "A"
+      name    = %[2]q
+      address = "198.51.100.71"
+    },
+    {
+      type    = "A"
+      name    = "www.%[2]s"
+      address = "198.51.100.72"
+    },
+  ]
+}
+
+resource "spaceship_dns_records" "team_b" {
+  domain      = %[1]q
+  owned_names = [%[3]q]
+
+  records = [
+    {
+      type    = "A"
+      name    = "api.%[3]s"
+      address = "198.51.100.73"
+    },
+  ]
+}
+`, domain, teamA, teamB)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("spaceship_dns_records.team_a", "records.#", "2"),
+					resource.TestCheckResourceAttr("spaceship_dns_records.team_b", "records.#", "1"),
+					testAccCheckDNSRecordPresent(domain, "A", "www."+teamA),
+					testAccCheckDNSRecordPresent(domain, "A", "api."+teamB),
+				),
+			},
+			{
+				Config: config,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+		},
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testAccCheckDNSRecordAbsent(domain, "A", teamA),
+			testAccCheckDNSRecordAbsent(domain, "A", "www."+teamA),
+			testAccCheckDNSRecordAbsent(domain, "A", "api."+teamB),
+		),
+	})
+}
+
+func TestAccDNSRecords_recordOutsideOwnedNamesFailsPlan(t *testing.T) {
+	testAccPreCheck(t)
+
+	domain := testAccDomainValue()
+	prefix := testAccRecordPrefix()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "spaceship" {}
+
+resource "spaceship_dns_records" "test" {
+  domain      = %[1]q
+  owned_names = ["%[2]s-scoped"]
+
+  records = [
+    {
+      type    = "A"
+      name    = "%[2]s-elsewhere"
+      address = "198.51.100.74"
+    },
+  ]
+}
+`, domain, prefix),
+				ExpectError: regexp.MustCompile(`Record Outside owned_names`),
+			},
+		},
+	})
+}
+
 func testAccDNSRecordsConfig(domain string, records []testAccDNSRecord) string {
+	return testAccDNSRecordsPolicyConfig(domain, "", records)
+}
+
+// testAccDNSRecordsPolicyConfig renders the records config with an explicit
+// deletion_policy; an empty policy leaves the attribute at its default.
+func testAccDNSRecordsPolicyConfig(domain, policy string, records []testAccDNSRecord) string {
 	var b strings.Builder
 
 	for _, record := range records {
@@ -616,16 +881,34 @@ func testAccDNSRecordsConfig(domain string, records []testAccDNSRecord) string {
     },`)
 	}
 
+	policyLine := ""
+	if policy != "" {
+		policyLine = fmt.Sprintf("\n  deletion_policy = %q", policy)
+	}
+
 	return fmt.Sprintf(`
 provider "spaceship" {}
 
 resource "spaceship_dns_records" "test" {
-  domain = %q
+  domain = %q%s
 
   records = [%s
   ]
 }
-`, domain, b.String())
+`, domain, policyLine, b.String())
+}
+
+func testAccDNSRecordsZoneFileConfig(domain, zone string) string {
+	return fmt.Sprintf(`
+provider "spaceship" {}
+
+resource "spaceship_dns_records" "test" {
+  domain    = %q
+  zone_file = <<-EOT
+%s
+EOT
+}
+`, domain, zone)
 }
 
 func testAccProviderOnlyConfig() string {
@@ -0,0 +1,568 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/namecheap/go-spaceship-sdk/client"
+)
+
+// These tests drive spaceship_dns_records through the provider's protocol
+// server, so the framework's own checks (identity changes, plan consistency)
+// run as they would under Terraform, without needing the Terraform CLI.
+
+var testServerRecords = []client.DNSRecord{
+	{Type: "A", Name: "www", TTL: 3600, Address: "192.0.2.1"},
+}
+
+// fakeDNSAPI is a minimal records API. Every domain starts out with
+// testServerRecords, and upserts and deletes change it the way the real API
+// would.
+type fakeDNSAPI struct {
+	mu      sync.Mutex
+	zones   map[string][]client.DNSRecord
+	deleted []client.DNSRecord
+}
+
+func (f *fakeDNSAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	domain := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+	zone, ok := f.zones[domain]
+	if !ok {
+		zone = append([]client.DNSRecord(nil), testServerRecords...)
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"items": zone, "total": len(zone)})
+		return
+	case http.MethodPut:
+		var payload struct {
+			Items []client.DNSRecord `json:"items"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+		for _, record := range payload.Items {
+			zone = append(removeTestRecord(zone, record), record)
+		}
+	case http.MethodDelete:
+		var records []client.DNSRecord
+		_ = json.NewDecoder(r.Body).Decode(&records)
+		for _, record := range records {
+			zone = removeTestRecord(zone, record)
+		}
+		f.deleted = append(f.deleted, records...)
+	}
+	f.zones[domain] = zone
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func removeTestRecord(zone []client.DNSRecord, record client.DNSRecord) []client.DNSRecord {
+	var kept []client.DNSRecord
+	for _, candidate := range zone {
+		if client.RecordKey(candidate) != client.RecordKey(record) {
+			kept = append(kept, candidate)
+		}
+	}
+	return kept
+}
+
+// newDNSRecordsTestServer configures the provider against a fakeDNSAPI.
+func newDNSRecordsTestServer(t *testing.T) (tfprotov6.ProviderServer, *fakeDNSAPI) {
+	t.Helper()
+	ctx := context.Background()
+
+	fake := &fakeDNSAPI{zones: map[string][]client.DNSRecord{}}
+	api := httptest.NewServer(fake)
+	t.Cleanup(api.Close)
+	t.Setenv("SPACESHIP_BASE_URL", api.URL)
+
+	server, err := providerserver.NewProtocol6WithError(New("test")())()
+	if err != nil {
+		t.Fatalf("NewProtocol6WithError: %v", err)
+	}
+
+	configType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"api_key":    tftypes.String,
+		"api_secret": tftypes.String,
+	}}
+	config, err := tfprotov6.NewDynamicValue(configType, tftypes.NewValue(configType, map[string]tftypes.Value{
+		"api_key":    tftypes.NewValue(tftypes.String, "k"),
+		"api_secret": tftypes.NewValue(tftypes.String, "s"),
+	}))
+	if err != nil {
+		t.Fatalf("NewDynamicValue: %v", err)
+	}
+	resp, err := server.ConfigureProvider(ctx, &tfprotov6.ConfigureProviderRequest{Config: &config})
+	if err != nil {
+		t.Fatalf("ConfigureProvider: %v", err)
+	}
+	requireNoProtocolErrors(t, "ConfigureProvider", resp.Diagnostics)
+	return server, fake
+}
+
+// dnsRecordsTestModel returns a spaceship_dns_records model for domain with
+// testServerRecords declared and every other attribute null, as in a config
+// that sets only domain and records.
+func dnsRecordsTestModel(t *testing.T, domain string) dnsRecordsResourceModel {
+	t.Helper()
+	ctx := context.Background()
+
+	records, diags := flattenDNSRecords(ctx, testServerRecords)
+	if diags.HasError() {
+		t.Fatalf("flattenDNSRecords: %v", diags)
+	}
+	timeoutsType, diags := dnsRecordsTestSchema(t).TypeAtPath(ctx, path.Root("timeouts"))
+	if diags.HasError() {
+		t.Fatalf("TypeAtPath: %v", diags)
+	}
+
+	return dnsRecordsResourceModel{
+		ID:             types.StringNull(),
+		Domain:         types.StringValue(domain),
+		Force:          types.BoolNull(),
+		Records:        records,
+		ZoneFile:       types.StringNull(),
+		DeletionPolicy: types.StringNull(),
+		OwnedNames:     types.SetNull(types.StringType),
+		Timeouts:       timeouts.Value{Object: types.ObjectNull(timeoutsType.(attr.TypeWithAttributeTypes).AttributeTypes())},
+	}
+}
+
+func dnsRecordsTestSchema(t *testing.T) schema.Schema {
+	t.Helper()
+	resp := &fwresource.SchemaResponse{}
+	(&dnsRecordsResource{}).Schema(context.Background(), fwresource.SchemaRequest{}, resp)
+	return resp.Schema
+}
+
+// dnsRecordsDynamicValue encodes model the way Terraform sends state, plans
+// and configuration over the protocol.
+func dnsRecordsDynamicValue(t *testing.T, model dnsRecordsResourceModel) *tfprotov6.DynamicValue {
+	t.Helper()
+	ctx := context.Background()
+
+	state := tfsdk.State{Schema: dnsRecordsTestSchema(t)}
+	objectType := state.Schema.Type().TerraformType(ctx)
+	state.Raw = tftypes.NewValue(objectType, nil)
+	if diags := state.Set(ctx, &model); diags.HasError() {
+		t.Fatalf("state.Set: %v", diags)
+	}
+	value, err := tfprotov6.NewDynamicValue(objectType, state.Raw)
+	if err != nil {
+		t.Fatalf("NewDynamicValue: %v", err)
+	}
+	return &value
+}
+
+// decodeDNSRecordsValue is the inverse of dnsRecordsDynamicValue.
+func decodeDNSRecordsValue(t *testing.T, value *tfprotov6.DynamicValue) dnsRecordsResourceModel {
+	t.Helper()
+	ctx := context.Background()
+
+	state := tfsdk.State{Schema: dnsRecordsTestSchema(t)}
+	raw, err := value.Unmarshal(state.Schema.Type().TerraformType(ctx))
+	if err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	state.Raw = raw
+
+	var model dnsRecordsResourceModel
+	if diags := state.Get(ctx, &model); diags.HasError() {
+		t.Fatalf("state.Get: %v", diags)
+	}
+	return model
+}
+
+func requireNoProtocolErrors(t *testing.T, call string, diags []*tfprotov6.Diagnostic) {
+	t.Helper()
+	for _, d := range diags {
+		if d.Severity == tfprotov6.DiagnosticSeverityError {
+			t.Fatalf("%s: %s: %s", call, d.Summary, d.Detail)
+		}
+	}
+}
+
+// State written before deletion_policy existed has it null. Read must
+// backfill the default so the next plan, with deletion_policy still unset
+// in the configuration, proposes no change.
+func TestDNSRecordsResource_ReadBackfillsDeletionPolicy(t *testing.T) {
+	ctx := context.Background()
+	server, _ := newDNSRecordsTestServer(t)
+
+	prior := dnsRecordsTestModel(t, "example.com")
+	prior.ID = types.StringValue("example.com")
+	prior.Force = types.BoolValue(true)
+
+	readResp, err := server.ReadResource(ctx, &tfprotov6.ReadResourceRequest{
+		TypeName:     "spaceship_dns_records",
+		CurrentState: dnsRecordsDynamicValue(t, prior),
+	})
+	if err != nil {
+		t.Fatalf("ReadResource: %v", err)
+	}
+	requireNoProtocolErrors(t, "ReadResource", readResp.Diagnostics)
+
+	if got := decodeDNSRecordsValue(t, readResp.NewState).DeletionPolicy; got.ValueString() != deletionPolicyAuthoritative {
+		t.Fatalf("deletion_policy after Read = %s, want %q", got, deletionPolicyAuthoritative)
+	}
+
+	config := dnsRecordsDynamicValue(t, dnsRecordsTestModel(t, "example.com"))
+	planResp, err := server.PlanResourceChange(ctx, &tfprotov6.PlanResourceChangeRequest{
+		TypeName:         "spaceship_dns_records",
+		PriorState:       readResp.NewState,
+		ProposedNewState: readResp.NewState,
+		Config:           config,
+		PriorIdentity:    readResp.NewIdentity,
+	})
+	if err != nil {
+		t.Fatalf("PlanResourceChange: %v", err)
+	}
+	requireNoProtocolErrors(t, "PlanResourceChange", planResp.Diagnostics)
+
+	objectType := dnsRecordsTestSchema(t).Type().TerraformType(ctx)
+	plannedRaw, err := planResp.PlannedState.Unmarshal(objectType)
+	if err != nil {
+		t.Fatalf("Unmarshal planned state: %v", err)
+	}
+	priorRaw, err := readResp.NewState.Unmarshal(objectType)
+	if err != nil {
+		t.Fatalf("Unmarshal read state: %v", err)
+	}
+	if !plannedRaw.Equal(priorRaw) {
+		t.Errorf("plan proposes a change after Read:\nprior:   %v\nplanned: %v", priorRaw, plannedRaw)
+	}
+}
+
+// Changing domain is an in-place update, so the identity changes with it. The
+// framework rejects that after the writes have gone out unless the resource
+// declares its identity mutable.
+func TestDNSRecordsResource_UpdateDomainInPlace(t *testing.T) {
+	ctx := context.Background()
+	server, _ := newDNSRecordsTestServer(t)
+
+	prior := dnsRecordsTestModel(t, "example.com")
+	prior.ID = types.StringValue("example.com")
+	prior.Force = types.BoolValue(true)
+	prior.DeletionPolicy = types.StringValue(deletionPolicyAuthoritative)
+
+	readResp, err := server.ReadResource(ctx, &tfprotov6.ReadResourceRequest{
+		TypeName:     "spaceship_dns_records",
+		CurrentState: dnsRecordsDynamicValue(t, prior),
+	})
+	if err != nil {
+		t.Fatalf("ReadResource: %v", err)
+	}
+	requireNoProtocolErrors(t, "ReadResource", readResp.Diagnostics)
+
+	config := dnsRecordsDynamicValue(t, dnsRecordsTestModel(t, "example.org"))
+	proposed := prior
+	proposed.Domain = types.StringValue("example.org")
+	planResp, err := server.PlanResourceChange(ctx, &tfprotov6.PlanResourceChangeRequest{
+		TypeName:         "spaceship_dns_records",
+		PriorState:       readResp.NewState,
+		ProposedNewState: dnsRecordsDynamicValue(t, proposed),
+		Config:           config,
+		PriorIdentity:    readResp.NewIdentity,
+	})
+	if err != nil {
+		t.Fatalf("PlanResourceChange: %v", err)
+	}
+	requireNoProtocolErrors(t, "PlanResourceChange", planResp.Diagnostics)
+	if len(planResp.RequiresReplace) > 0 {
+		t.Fatalf("domain change planned a replacement: %v", planResp.RequiresReplace)
+	}
+
+	applyResp, err := server.ApplyResourceChange(ctx, &tfprotov6.ApplyResourceChangeRequest{
+		TypeName:        "spaceship_dns_records",
+		PriorState:      readResp.NewState,
+		PlannedState:    planResp.PlannedState,
+		Config:          config,
+		PlannedIdentity: planResp.PlannedIdentity,
+	})
+	if err != nil {
+		t.Fatalf("ApplyResourceChange: %v", err)
+	}
+	requireNoProtocolErrors(t, "ApplyResourceChange", applyResp.Diagnostics)
+
+	identityResp := &fwresource.IdentitySchemaResponse{}
+	(&dnsRecordsResource{}).IdentitySchema(ctx, fwresource.IdentitySchemaRequest{}, identityResp)
+	identity, err := applyResp.NewIdentity.IdentityData.Unmarshal(identityResp.IdentitySchema.Type().TerraformType(ctx))
+	if err != nil {
+		t.Fatalf("Unmarshal identity: %v", err)
+	}
+	want := tftypes.NewValue(identity.Type(), map[string]tftypes.Value{
+		"domain": tftypes.NewValue(tftypes.String, "example.org"),
+	})
+	if !identity.Equal(want) {
+		t.Errorf("identity after apply = %v, want %v", identity, want)
+	}
+	if got := decodeDNSRecordsValue(t, applyResp.NewState).ID.ValueString(); got != "example.org" {
+		t.Errorf("id after apply = %q, want example.org", got)
+	}
+}
+
+func applyDNSRecords(t *testing.T, server tfprotov6.ProviderServer, prior *tfprotov6.DynamicValue, private []byte, planned *tfprotov6.DynamicValue) *tfprotov6.ApplyResourceChangeResponse {
+	t.Helper()
+	resp, err := server.ApplyResourceChange(context.Background(), &tfprotov6.ApplyResourceChangeRequest{
+		TypeName:       "spaceship_dns_records",
+		PriorState:     prior,
+		PlannedState:   planned,
+		Config:         planned,
+		PlannedPrivate: private,
+	})
+	if err != nil {
+		t.Fatalf("ApplyResourceChange: %v", err)
+	}
+	requireNoProtocolErrors(t, "ApplyResourceChange", resp.Diagnostics)
+	return resp
+}
+
+// An additive instance owns only the records it created. Declaring www, which
+// is already live, adopts it into state, but neither removing it from records
+// nor destroying the resource deletes it; the record the instance created is
+// deleted in both cases.
+func TestDNSRecordsResource_AdditiveDeletesOnlyCreatedRecords(t *testing.T) {
+	ctx := context.Background()
+	objectType := dnsRecordsTestSchema(t).Type().TerraformType(ctx)
+	null, err := tfprotov6.NewDynamicValue(objectType, tftypes.NewValue(objectType, nil))
+	if err != nil {
+		t.Fatalf("NewDynamicValue: %v", err)
+	}
+
+	created := client.DNSRecord{Type: "A", Name: "api", TTL: 3600, Address: "192.0.2.2"}
+	create := func(t *testing.T, server tfprotov6.ProviderServer) *tfprotov6.ApplyResourceChangeResponse {
+		records, diags := flattenDNSRecords(ctx, append([]client.DNSRecord{created}, testServerRecords...))
+		if diags.HasError() {
+			t.Fatalf("flattenDNSRecords: %v", diags)
+		}
+		planned := dnsRecordsTestModel(t, "example.com")
+		planned.ID = types.StringUnknown()
+		planned.Force = types.BoolValue(true)
+		planned.DeletionPolicy = types.StringValue(deletionPolicyAdditive)
+		planned.Records = records
+		return applyDNSRecords(t, server, &null, nil, dnsRecordsDynamicValue(t, planned))
+	}
+	requireDeleted := func(t *testing.T, api *fakeDNSAPI) {
+		t.Helper()
+		if len(api.deleted) != 1 || client.RecordKey(api.deleted[0]) != client.RecordKey(created) {
+			t.Errorf("deleted %+v, want only %+v", api.deleted, created)
+		}
+	}
+
+	t.Run("update", func(t *testing.T) {
+		server, api := newDNSRecordsTestServer(t)
+		createResp := create(t, server)
+
+		planned := decodeDNSRecordsValue(t, createResp.NewState)
+		records, diags := flattenDNSRecords(ctx, nil)
+		if diags.HasError() {
+			t.Fatalf("flattenDNSRecords: %v", diags)
+		}
+		planned.Records = records
+		applyDNSRecords(t, server, createResp.NewState, createResp.Private, dnsRecordsDynamicValue(t, planned))
+		requireDeleted(t, api)
+	})
+
+	t.Run("destroy", func(t *testing.T) {
+		server, api := newDNSRecordsTestServer(t)
+		createResp := create(t, server)
+
+		applyDNSRecords(t, server, createResp.NewState, createResp.Private, &null)
+		requireDeleted(t, api)
+	})
+}
+
+// scopedTestModel limits dnsRecordsTestModel to owned_names = ["dev"], which
+// does not cover the declared records.
+func scopedTestModel(t *testing.T) dnsRecordsResourceModel {
+	t.Helper()
+	model := dnsRecordsTestModel(t, "example.com")
+	model.ID = types.StringUnknown()
+	model.Force = types.BoolValue(true)
+	model.DeletionPolicy = types.StringValue(deletionPolicyAuthoritative)
+	model.OwnedNames = types.SetValueMust(types.StringType, []attr.Value{types.StringValue("dev")})
+	return model
+}
+
+func requireOutOfScopeError(t *testing.T, call string, diags []*tfprotov6.Diagnostic) {
+	t.Helper()
+	for _, d := range diags {
+		if d.Severity == tfprotov6.DiagnosticSeverityError && d.Summary == "Record Outside owned_names" {
+			return
+		}
+	}
+	t.Fatalf("%s: expected a Record Outside owned_names error, got %v", call, diags)
+}
+
+// The scope check only needs record names, so an unknown address must not
+// defer it to the apply.
+func TestDNSRecordsResource_PlanRejectsOutOfScopeRecordWithUnknowns(t *testing.T) {
+	ctx := context.Background()
+	server, _ := newDNSRecordsTestServer(t)
+
+	objectType := dnsRecordsTestSchema(t).Type().TerraformType(ctx)
+	null, err := tfprotov6.NewDynamicValue(objectType, tftypes.NewValue(objectType, nil))
+	if err != nil {
+		t.Fatalf("NewDynamicValue: %v", err)
+	}
+
+	// The address comes from a resource created in the same apply.
+	proposed := scopedTestModel(t)
+	attributes := proposed.Records.Elements()[0].(types.Object).Attributes()
+	attributes["address"] = types.StringUnknown()
+	record, diags := types.ObjectValue(dnsRecordObjectType.AttrTypes, attributes)
+	if diags.HasError() {
+		t.Fatalf("ObjectValue: %v", diags)
+	}
+	proposed.Records, diags = types.ListValue(dnsRecordObjectType, []attr.Value{record})
+	if diags.HasError() {
+		t.Fatalf("ListValue: %v", diags)
+	}
+	config := proposed
+	config.ID = types.StringNull()
+	config.Force = types.BoolNull()
+	config.DeletionPolicy = types.StringNull()
+
+	resp, err := server.PlanResourceChange(ctx, &tfprotov6.PlanResourceChangeRequest{
+		TypeName:         "spaceship_dns_records",
+		PriorState:       &null,
+		ProposedNewState: dnsRecordsDynamicValue(t, proposed),
+		Config:           dnsRecordsDynamicValue(t, config),
+	})
+	if err != nil {
+		t.Fatalf("PlanResourceChange: %v", err)
+	}
+	requireOutOfScopeError(t, "PlanResourceChange", resp.Diagnostics)
+}
+
+// Create checks the scope itself before writing, rather than trusting a plan
+// that may have skipped records whose name was unknown.
+func TestDNSRecordsResource_CreateRejectsOutOfScopeRecord(t *testing.T) {
+	ctx := context.Background()
+	server, api := newDNSRecordsTestServer(t)
+
+	objectType := dnsRecordsTestSchema(t).Type().TerraformType(ctx)
+	null, err := tfprotov6.NewDynamicValue(objectType, tftypes.NewValue(objectType, nil))
+	if err != nil {
+		t.Fatalf("NewDynamicValue: %v", err)
+	}
+
+	planned := scopedTestModel(t)
+	// A record that is not live yet, so a write would show up in api.zones.
+	records, diags := flattenDNSRecords(ctx, []client.DNSRecord{{Type: "A", Name: "api", TTL: 3600, Address: "192.0.2.2"}})
+	if diags.HasError() {
+		t.Fatalf("flattenDNSRecords: %v", diags)
+	}
+	planned.Records = records
+
+	resp, err := server.ApplyResourceChange(ctx, &tfprotov6.ApplyResourceChangeRequest{
+		TypeName:     "spaceship_dns_records",
+		PriorState:   &null,
+		PlannedState: dnsRecordsDynamicValue(t, planned),
+		Config:       dnsRecordsDynamicValue(t, planned),
+	})
+	if err != nil {
+		t.Fatalf("ApplyResourceChange: %v", err)
+	}
+	requireOutOfScopeError(t, "ApplyResourceChange", resp.Diagnostics)
+	if len(api.zones) != 0 {
+		t.Errorf("Create wrote to the API before rejecting the record: %v", api.zones)
+	}
+}
+
+// A new resource that duplicates a record an existing, unchanged resource
+// holds is rejected at plan time, unless the existing one is being destroyed.
+func TestDNSRecordsResource_PlanRejectsRecordHeldByExistingResource(t *testing.T) {
+	ctx := context.Background()
+	objectType := dnsRecordsTestSchema(t).Type().TerraformType(ctx)
+	null, err := tfprotov6.NewDynamicValue(objectType, tftypes.NewValue(objectType, nil))
+	if err != nil {
+		t.Fatalf("NewDynamicValue: %v", err)
+	}
+
+	existing := dnsRecordsTestModel(t, "example.com")
+	existing.ID = types.StringValue("example.com")
+	existing.Force = types.BoolValue(true)
+	existing.DeletionPolicy = types.StringValue(deletionPolicyAdditive)
+
+	duplicate := dnsRecordsTestModel(t, "example.com")
+	duplicate.ID = types.StringUnknown()
+	duplicate.Force = types.BoolValue(true)
+	duplicate.DeletionPolicy = types.StringValue(deletionPolicyAdditive)
+
+	refresh := func(t *testing.T, server tfprotov6.ProviderServer) *tfprotov6.ReadResourceResponse {
+		t.Helper()
+		resp, err := server.ReadResource(ctx, &tfprotov6.ReadResourceRequest{
+			TypeName:     "spaceship_dns_records",
+			CurrentState: dnsRecordsDynamicValue(t, existing),
+		})
+		if err != nil {
+			t.Fatalf("ReadResource: %v", err)
+		}
+		requireNoProtocolErrors(t, "ReadResource", resp.Diagnostics)
+		return resp
+	}
+	planCreate := func(t *testing.T, server tfprotov6.ProviderServer) []*tfprotov6.Diagnostic {
+		t.Helper()
+		resp, err := server.PlanResourceChange(ctx, &tfprotov6.PlanResourceChangeRequest{
+			TypeName:         "spaceship_dns_records",
+			PriorState:       &null,
+			ProposedNewState: dnsRecordsDynamicValue(t, duplicate),
+			Config:           dnsRecordsDynamicValue(t, dnsRecordsTestModel(t, "example.com")),
+		})
+		if err != nil {
+			t.Fatalf("PlanResourceChange: %v", err)
+		}
+		return resp.Diagnostics
+	}
+
+	t.Run("kept", func(t *testing.T) {
+		server, _ := newDNSRecordsTestServer(t)
+		refresh(t, server)
+
+		for _, d := range planCreate(t, server) {
+			if d.Severity == tfprotov6.DiagnosticSeverityError && d.Summary == "Duplicate DNS Record Across Resources" {
+				return
+			}
+		}
+		t.Fatal("expected a Duplicate DNS Record Across Resources error")
+	})
+
+	t.Run("destroyed", func(t *testing.T) {
+		server, _ := newDNSRecordsTestServer(t)
+		readResp := refresh(t, server)
+
+		destroyResp, err := server.PlanResourceChange(ctx, &tfprotov6.PlanResourceChangeRequest{
+			TypeName:         "spaceship_dns_records",
+			PriorState:       readResp.NewState,
+			ProposedNewState: &null,
+			Config:           &null,
+			PriorIdentity:    readResp.NewIdentity,
+		})
+		if err != nil {
+			t.Fatalf("PlanResourceChange: %v", err)
+		}
+		requireNoProtocolErrors(t, "PlanResourceChange (destroy)", destroyResp.Diagnostics)
+
+		requireNoProtocolErrors(t, "PlanResourceChange (create)", planCreate(t, server))
+	})
+}
@@ -2,10 +2,16 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
@@ -351,3 +357,234 @@ func TestRecordValueSignatureTLSA(t *testing.T) {
 		t.Fatalf("expected TLSA signatures to match despite spacing and case differences")
 	}
 }
+
+// newListClient returns a client whose every request is answered with items
+// as a paginated list response ({"items": ..., "total": ...}), or fails with
+// status when it is not zero. It serves both the DNS record and the domain
+// list endpoints.
+func newListClient(t *testing.T, status int, items []map[string]any) *client.Client {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if status != 0 {
+			w.WriteHeader(status)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"items": items, "total": len(items)})
+	}))
+	t.Cleanup(server.Close)
+
+	c, err := client.NewClient(server.URL, "k", "s")
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	return c
+}
+
+// newRecordListResource wires the resource the way Configure does, with the
+// shared cache in front of the client.
+func newRecordListResource(c *client.Client) *dnsRecordsResource {
+	return &dnsRecordsResource{client: c, records: newDNSRecordCache(c), claims: newDNSRecordRegistry()}
+}
+
+func defaultReadTimeout(_ context.Context, d time.Duration) (time.Duration, diag.Diagnostics) {
+	return d, nil
+}
+
+// The preview lists records that exist only in the live zone (created outside
+// Terraform) as deletions, next to the upserts from the desired set.
+func TestPreviewRecordChanges_ListsLiveDeletionsAndUpserts(t *testing.T) {
+	r := newRecordListResource(newListClient(t, 0, []map[string]any{
+		{"type": "A", "name": "@", "ttl": 3600, "address": "192.0.2.1"},
+		{"type": "TXT", "name": "manual", "ttl": 300, "value": "added in the console"},
+	}))
+
+	desired := []client.DNSRecord{
+		{Type: "A", Name: "@", TTL: 3600, Address: "192.0.2.1"},
+		{Type: "MX", Name: "@", TTL: 3600, Exchange: "mail.example.com", Preference: intPtr(10)},
+	}
+
+	diags := r.previewRecordChanges(context.Background(), "example.com", defaultReadTimeout, desired, nil)
+	if diags.HasError() || diags.WarningsCount() != 1 {
+		t.Fatalf("expected exactly one warning, got %v", diags)
+	}
+
+	detail := diags.Warnings()[0].Detail()
+	for _, want := range []string{
+		"\n  - manual 300 TXT \"added in the console\"",
+		"\n  + @ 3600 MX 10 mail.example.com.",
+	} {
+		if !strings.Contains(detail, want) {
+			t.Errorf("expected warning detail to contain %q, got:\n%s", want, detail)
+		}
+	}
+	if strings.Contains(detail, "192.0.2.1") {
+		t.Errorf("unchanged records must not be listed, got:\n%s", detail)
+	}
+}
+
+func TestPreviewRecordChanges_NoChangesNoWarning(t *testing.T) {
+	r := newRecordListResource(newListClient(t, 0, []map[string]any{
+		{"type": "A", "name": "@", "ttl": 3600, "address": "192.0.2.1"},
+	}))
+
+	desired := []client.DNSRecord{{Type: "A", Name: "@", TTL: 3600, Address: "192.0.2.1"}}
+	if diags := r.previewRecordChanges(context.Background(), "example.com", defaultReadTimeout, desired, nil); len(diags) != 0 {
+		t.Fatalf("expected no diagnostics, got %v", diags)
+	}
+}
+
+// A failed read must not fail the plan: the preview is advisory.
+func TestPreviewRecordChanges_ReadFailureIsWarning(t *testing.T) {
+	r := newRecordListResource(newListClient(t, http.StatusInternalServerError, nil))
+
+	diags := r.previewRecordChanges(context.Background(), "example.com", defaultReadTimeout, nil, nil)
+	if diags.HasError() || diags.WarningsCount() != 1 {
+		t.Fatalf("expected one warning and no errors, got %v", diags)
+	}
+	if got := diags.Warnings()[0].Summary(); got != "Unable to preview DNS record changes" {
+		t.Errorf("unexpected summary %q", got)
+	}
+}
+
+// toDelete here holds records removed from records or never declared. Additive
+// mode does not skip deletions outright: a record it created and that was then
+// removed from records is deleted, so it does not stay live and untracked.
+func TestPermittedDeletions(t *testing.T) {
+	owned := client.DNSRecord{Type: "A", Name: "www", TTL: 3600, Address: "192.0.2.1"}
+	manual := client.DNSRecord{Type: "TXT", Name: "manual", TTL: 300, Value: "added in the console"}
+	toDelete := []client.DNSRecord{owned, manual}
+
+	authoritative := types.StringValue(deletionPolicyAuthoritative)
+	additive := types.StringValue(deletionPolicyAdditive)
+
+	cases := map[string]struct {
+		policy, priorPolicy types.String
+		owned               []client.DNSRecord
+		expected            int
+	}{
+		"authoritative deletes everything":                      {policy: authoritative, priorPolicy: authoritative, expected: 2},
+		"additive on create deletes nothing":                    {policy: additive, priorPolicy: types.StringNull(), expected: 0},
+		"switch to additive deletes nothing":                    {policy: additive, priorPolicy: authoritative, owned: toDelete, expected: 0},
+		"additive update keeps records it did not create":       {policy: additive, priorPolicy: additive, expected: 0},
+		"additive update deletes created records removed later": {policy: additive, priorPolicy: additive, owned: []client.DNSRecord{owned}, expected: 1},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := permittedDeletions(tc.policy, tc.priorPolicy, tc.owned, toDelete)
+			if len(got) != tc.expected {
+				t.Fatalf("expected %d deletions, got %d: %+v", tc.expected, len(got), got)
+			}
+			if tc.expected == 1 && client.RecordKey(got[0]) != client.RecordKey(owned) {
+				t.Errorf("expected the owned record to be deleted, got %+v", got[0])
+			}
+		})
+	}
+}
+
+func TestCreatedRecords(t *testing.T) {
+	adopted := client.DNSRecord{Type: "A", Name: "www", TTL: 3600, Address: "192.0.2.1"}
+	retuned := client.DNSRecord{Type: "A", Name: "www", TTL: 60, Address: "192.0.2.1"}
+	earlier := client.DNSRecord{Type: "A", Name: "api", TTL: 3600, Address: "192.0.2.2"}
+	removed := client.DNSRecord{Type: "A", Name: "old", TTL: 3600, Address: "192.0.2.3"}
+	fresh := client.DNSRecord{Type: "TXT", Name: "@", TTL: 300, Value: "v=spf1 -all"}
+
+	existing := []client.DNSRecord{adopted, earlier, removed}
+	desired := []client.DNSRecord{retuned, earlier, fresh}
+	// diffDNSRecords upserts the TTL change on www and the new TXT record.
+	toUpsert := []client.DNSRecord{retuned, fresh}
+
+	got := createdRecords([]client.DNSRecord{earlier, removed}, existing, desired, toUpsert)
+	keys := make([]string, len(got))
+	for i, record := range got {
+		keys[i] = client.RecordKey(record)
+	}
+	expected := []string{client.RecordKey(earlier), client.RecordKey(fresh)}
+	if strings.Join(keys, ",") != strings.Join(expected, ",") {
+		t.Errorf("expected %v, got %v", expected, keys)
+	}
+}
+
+// In additive mode the preview must not list records the apply leaves alone.
+func TestPreviewRecordChanges_AdditiveSkipsUnownedDeletions(t *testing.T) {
+	r := newRecordListResource(newListClient(t, 0, []map[string]any{
+		{"type": "TXT", "name": "manual", "ttl": 300, "value": "added in the console"},
+	}))
+
+	desired := []client.DNSRecord{{Type: "A", Name: "@", TTL: 3600, Address: "192.0.2.1"}}
+	permit := func(toDelete []client.DNSRecord) []client.DNSRecord {
+		return permittedDeletions(types.StringValue(deletionPolicyAdditive), types.StringValue(deletionPolicyAdditive), nil, toDelete)
+	}
+
+	diags := r.previewRecordChanges(context.Background(), "example.com", defaultReadTimeout, desired, permit)
+	if diags.WarningsCount() != 1 {
+		t.Fatalf("expected exactly one warning, got %v", diags)
+	}
+	if detail := diags.Warnings()[0].Detail(); strings.Contains(detail, "manual") {
+		t.Errorf("unowned record must not be listed as a deletion, got:\n%s", detail)
+	}
+}
+
+func TestScopeRecords(t *testing.T) {
+	live := []client.DNSRecord{
+		{Type: "A", Name: "@", Address: "192.0.2.1"},
+		{Type: "A", Name: "dev", Address: "192.0.2.2"},
+		{Type: "A", Name: "API.dev", Address: "192.0.2.3"},
+		{Type: "A", Name: "*.dev", Address: "192.0.2.4"},
+		{Type: "A", Name: "nodev", Address: "192.0.2.5"},
+		{Type: "A", Name: "www", Address: "192.0.2.6"},
+	}
+
+	cases := map[string]struct {
+		scope    []string
+		expected []string
+	}{
+		"unset keeps everything": {scope: nil, expected: []string{"@", "dev", "API.dev", "*.dev", "nodev", "www"}},
+		"subdomain suffix":       {scope: []string{"dev"}, expected: []string{"dev", "API.dev", "*.dev"}},
+		"apex is exact":          {scope: []string{"@"}, expected: []string{"@"}},
+		"several entries":        {scope: []string{"@", "www"}, expected: []string{"@", "www"}},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := scopeRecords(live, tc.scope)
+			names := make([]string, len(got))
+			for i, record := range got {
+				names[i] = record.Name
+			}
+			if strings.Join(names, ",") != strings.Join(tc.expected, ",") {
+				t.Errorf("expected %v, got %v", tc.expected, names)
+			}
+		})
+	}
+}
+
+func TestValidateRecordsInScope(t *testing.T) {
+	desired := []client.DNSRecord{
+		{Type: "A", Name: "api.dev", Address: "192.0.2.1"},
+		{Type: "A", Name: "www", Address: "192.0.2.2"},
+	}
+
+	diags := validateRecordsInScope(desired, []string{"dev"}, true)
+	if diags.ErrorsCount() != 1 {
+		t.Fatalf("expected one error, got %v", diags)
+	}
+	withPath, ok := diags.Errors()[0].(diag.DiagnosticWithPath)
+	if !ok {
+		t.Fatalf("expected an attribute error, got %T", diags.Errors()[0])
+	}
+	if want := path.Root("records").AtListIndex(1).AtName("name"); !withPath.Path().Equal(want) {
+		t.Errorf("expected error at %s, got %s", want, withPath.Path())
+	}
+
+	if diags := validateRecordsInScope(desired, nil, true); diags.HasError() {
+		t.Errorf("unset owned_names must accept every record, got %v", diags)
+	}
+
+	// plannedRecordNames leaves a name empty while it is unknown.
+	if diags := validateRecordsInScope([]client.DNSRecord{{Type: "A"}}, []string{"dev"}, true); diags.HasError() {
+		t.Errorf("a record with an unknown name must be skipped, got %v", diags)
+	}
+}
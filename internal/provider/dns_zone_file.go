@@ -0,0 +1,781 @@
+package provider
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/namecheap/go-spaceship-sdk/client"
+)
+
+// txtChunkSize is the RFC 1035 limit for a single <character-string>. Longer
+// TXT values are split into several quoted strings on one line, which
+// resolvers concatenate back into the original value.
+const txtChunkSize = 255
+
+// renderZoneFile renders a domain's records as an RFC 1035 master file.
+// Owner names stay relative to $ORIGIN (the API stores them that way) and
+// every target hostname is written fully qualified, since the API stores
+// targets as absolute names without the trailing dot. ALIAS has no RFC type;
+// it is emitted in the de-facto `ALIAS target.` form most DNS software reads.
+//
+// Records are sorted (apex first, then by name, type and data) so the output
+// only changes when the zone does, regardless of the API's paging order.
+func renderZoneFile(domain string, records []client.DNSRecord) string {
+	sorted := slices.Clone(records)
+	slices.SortStableFunc(sorted, func(a, b client.DNSRecord) int {
+		if c := compareOwnerNames(a.Name, b.Name); c != 0 {
+			return c
+		}
+		if c := strings.Compare(strings.ToUpper(a.Type), strings.ToUpper(b.Type)); c != 0 {
+			return c
+		}
+		return strings.Compare(client.RecordValueSignature(a), client.RecordValueSignature(b))
+	})
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "$ORIGIN %s\n", absoluteName(strings.ToLower(domain)))
+	fmt.Fprintf(&b, "$TTL %d\n", defaultRecordTTL)
+	for _, record := range sorted {
+		ttl := record.TTL
+		if ttl <= 0 {
+			ttl = int(defaultRecordTTL)
+		}
+		fmt.Fprintf(&b, "%s\t%d\tIN\t%s\t%s\n", zoneOwner(record), ttl, strings.ToUpper(record.Type), zoneRData(record))
+	}
+	return b.String()
+}
+
+// compareOwnerNames orders the apex ("@") before every other name, then
+// case-insensitively.
+func compareOwnerNames(a, b string) int {
+	switch {
+	case a == b:
+		return 0
+	case a == "@":
+		return -1
+	case b == "@":
+		return 1
+	}
+	return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+}
+
+// zoneOwner builds the owner name for a record. SRV, TLSA and (when a port is
+// set) HTTPS/SVCB records carry their underscore-prefixed labels as separate
+// API fields; in a zone file those labels are part of the owner name.
+func zoneOwner(record client.DNSRecord) string {
+	var labels []string
+	switch strings.ToUpper(record.Type) {
+	case "SRV":
+		labels = append(labels, record.Service, record.Protocol)
+	case "TLSA":
+		labels = append(labels, portLabel(record.Port), record.Protocol)
+	case "HTTPS", "SVCB":
+		if port := portLabel(record.Port); port != "" {
+			labels = append(labels, port)
+			if record.Scheme != "" {
+				labels = append(labels, record.Scheme)
+			}
+		}
+	}
+	if len(labels) == 0 {
+		return record.Name
+	}
+	if record.Name != "@" {
+		labels = append(labels, record.Name)
+	}
+	return strings.Join(labels, ".")
+}
+
+// zoneRData renders the type-specific data of a record in presentation format.
+func zoneRData(record client.DNSRecord) string {
+	switch strings.ToUpper(record.Type) {
+	case "A", "AAAA":
+		return record.Address
+	case "ALIAS":
+		return absoluteName(record.AliasName)
+	case "CAA":
+		return fmt.Sprintf("%s %s %s", intValue(record.Flag), record.Tag, quoteCharacterString(record.Value))
+	case "CNAME":
+		return absoluteName(record.CName)
+	case "HTTPS", "SVCB":
+		rdata := fmt.Sprintf("%s %s", intValue(record.SvcPriority), absoluteName(record.TargetName))
+		if record.SvcParams != "" {
+			rdata += " " + record.SvcParams
+		}
+		return rdata
+	case "MX":
+		return fmt.Sprintf("%s %s", intValue(record.Preference), absoluteName(record.Exchange))
+	case "NS":
+		return absoluteName(record.Nameserver)
+	case "PTR":
+		return absoluteName(record.Pointer)
+	case "SRV":
+		port := ""
+		if record.Port != nil && record.Port.Int != nil {
+			port = strconv.Itoa(*record.Port.Int)
+		}
+		return fmt.Sprintf("%s %s %s %s", intValue(record.Priority), intValue(record.Weight), port, absoluteName(record.Target))
+	case "TLSA":
+		return fmt.Sprintf("%s %s %s %s", intValue(record.Usage), intValue(record.Selector), intValue(record.Matching), strings.ReplaceAll(record.AssociationData, " ", ""))
+	case "TXT":
+		return quoteTXT(record.Value)
+	default:
+		return record.Address
+	}
+}
+
+// absoluteName appends the root label so a hostname is not read relative to
+// $ORIGIN. The root name (".") is already absolute.
+func absoluteName(name string) string {
+	if strings.HasSuffix(name, ".") {
+		return name
+	}
+	return name + "."
+}
+
+// portLabel returns the string-form port label ("_443", "*") of a record.
+func portLabel(port *client.PortValue) string {
+	if port == nil {
+		return ""
+	}
+	if port.String != nil {
+		return *port.String
+	}
+	if port.Int != nil {
+		return "_" + strconv.Itoa(*port.Int)
+	}
+	return ""
+}
+
+func intValue(value *int) string {
+	if value == nil {
+		return "0"
+	}
+	return strconv.Itoa(*value)
+}
+
+// quoteTXT renders a TXT value as one or more quoted character-strings of at
+// most txtChunkSize bytes each. Chunks are cut on byte boundaries, matching
+// how the API counts TXT length.
+func quoteTXT(value string) string {
+	if len(value) <= txtChunkSize {
+		return quoteCharacterString(value)
+	}
+	var chunks []string
+	for len(value) > 0 {
+		n := min(len(value), txtChunkSize)
+		chunks = append(chunks, quoteCharacterString(value[:n]))
+		value = value[n:]
+	}
+	return strings.Join(chunks, " ")
+}
+
+// quoteCharacterString quotes s as an RFC 1035 <character-string>: quotes and
+// backslashes are backslash-escaped, and bytes outside printable ASCII are
+// written as \DDD decimal escapes.
+func quoteCharacterString(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '"' || c == '\\':
+			b.WriteByte('\\')
+			b.WriteByte(c)
+		case c < 0x20 || c > 0x7e:
+			fmt.Fprintf(&b, "\\%03d", c)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+// zoneFileRecordTypes are the RR types the Spaceship API stores, in the order
+// error messages list them.
+var zoneFileRecordTypes = []string{"A", "AAAA", "ALIAS", "CAA", "CNAME", "HTTPS", "MX", "NS", "PTR", "SRV", "SVCB", "TLSA", "TXT"}
+
+// zoneFileError is a problem at a 1-based line of a zone file.
+type zoneFileError struct {
+	Line int
+	Msg  string
+}
+
+func (e *zoneFileError) Error() string {
+	return fmt.Sprintf("line %d: %s", e.Line, e.Msg)
+}
+
+// zoneFileErrors collects every problem found in one parse, in line order, so
+// a migration surfaces all unsupported records at once instead of one per
+// plan.
+type zoneFileErrors []*zoneFileError
+
+func (e zoneFileErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "\n")
+}
+
+func (e zoneFileErrors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, err := range e {
+		errs[i] = err
+	}
+	return errs
+}
+
+// zoneFileRecord is a parsed resource record and the line it starts on.
+type zoneFileRecord struct {
+	Line   int
+	Record client.DNSRecord
+}
+
+// parseZoneFile parses an RFC 1035 master file for domain into API records,
+// the inverse of renderZoneFile. Owner names come back relative to domain
+// (the apex as "@"), with the underscore labels of SRV, TLSA and HTTPS/SVCB
+// owners moved into their record fields; target hostnames are qualified
+// against $ORIGIN and stored without the trailing dot, as the API does.
+//
+// $ORIGIN and $TTL are honored and SOA records are skipped (Spaceship serves
+// its own). Records of other types, other classes, or owners outside domain
+// are reported as a zoneFileErrors listing every offending line (a syntax
+// error stops the parse and is the only entry); field values
+// are not range-checked here — that is left to the record validators.
+func parseZoneFile(text, domain string) ([]zoneFileRecord, error) {
+	entries, err := splitZoneEntries(text)
+	if err != nil {
+		return nil, zoneFileErrors{err}
+	}
+
+	zone := strings.ToLower(strings.TrimSuffix(domain, "."))
+	origin := zone + "."
+	ttl := int(defaultRecordTTL)
+	owner := ""
+
+	var (
+		records []zoneFileRecord
+		errs    zoneFileErrors
+	)
+	fail := func(line int, format string, args ...any) {
+		errs = append(errs, &zoneFileError{Line: line, Msg: fmt.Sprintf(format, args...)})
+	}
+
+	for _, entry := range entries {
+		tokens := entry.tokens
+
+		if first := tokens[0]; !first.quoted && strings.HasPrefix(first.text, "$") {
+			directive := strings.ToUpper(first.text)
+			switch directive {
+			case "$ORIGIN":
+				if len(tokens) != 2 {
+					fail(entry.line, "$ORIGIN takes exactly one domain name")
+					continue
+				}
+				origin = strings.ToLower(qualifyName(tokens[1].text, origin))
+			case "$TTL":
+				if len(tokens) != 2 {
+					fail(entry.line, "$TTL takes exactly one value")
+					continue
+				}
+				value, ok := parseZoneTTL(tokens[1].text)
+				if !ok {
+					fail(entry.line, "invalid $TTL %q", tokens[1].text)
+					continue
+				}
+				ttl = value
+			case "$INCLUDE":
+				fail(entry.line, "$INCLUDE is not supported; inline the included file")
+			default:
+				fail(entry.line, "unknown directive %s", first.text)
+			}
+			continue
+		}
+
+		if !entry.blankOwner {
+			if tokens[0].quoted {
+				fail(entry.line, "owner name must not be quoted")
+				continue
+			}
+			owner = qualifyName(tokens[0].text, origin)
+			tokens = tokens[1:]
+		} else if owner == "" {
+			fail(entry.line, "record has no owner name and there is no previous owner to inherit")
+			continue
+		}
+
+		recordTTL, tokens, err := splitTTLAndClass(tokens, ttl)
+		if err != nil {
+			fail(entry.line, "%s", err)
+			continue
+		}
+
+		recordType := strings.ToUpper(tokens[0].text)
+		if recordType == "SOA" {
+			continue
+		}
+		if !slices.Contains(zoneFileRecordTypes, recordType) {
+			fail(entry.line, "record type %s is not supported by Spaceship DNS (supported: %s)", recordType, strings.Join(zoneFileRecordTypes, ", "))
+			continue
+		}
+
+		name, ok := relativeOwnerName(owner, zone)
+		if !ok {
+			fail(entry.line, "owner %s is outside the zone %s", owner, zone)
+			continue
+		}
+
+		record := client.DNSRecord{Type: recordType, TTL: recordTTL}
+		if err := splitOwnerLabels(&record, name); err != nil {
+			fail(entry.line, "%s", err)
+			continue
+		}
+		if err := parseZoneRData(&record, tokens[1:], origin); err != nil {
+			fail(entry.line, "%s", err)
+			continue
+		}
+		records = append(records, zoneFileRecord{Line: entry.line, Record: record})
+	}
+
+	if len(errs) > 0 {
+		return records, errs
+	}
+	return records, nil
+}
+
+type zoneToken struct {
+	text   string
+	quoted bool
+}
+
+// zoneEntry is one logical zone-file line: parentheses may spread it over
+// several physical lines, and line is where it starts.
+type zoneEntry struct {
+	line       int
+	blankOwner bool
+	tokens     []zoneToken
+}
+
+// splitZoneEntries tokenizes a zone file into logical entries, dropping
+// comments and blank lines. Quoted tokens keep their escapes; bare tokens are
+// returned verbatim, including any embedded quoted section (svc params such as
+// alpn="h2,h3").
+func splitZoneEntries(text string) ([]zoneEntry, *zoneFileError) {
+	var (
+		entries   []zoneEntry
+		current   zoneEntry
+		line      = 1
+		depth     = 0
+		openLine  = 0
+		lineStart = true
+	)
+
+	flush := func() {
+		if len(current.tokens) > 0 {
+			entries = append(entries, current)
+		}
+		current = zoneEntry{}
+	}
+
+	for i := 0; i < len(text); {
+		c := text[i]
+
+		if lineStart && depth == 0 {
+			current.line = line
+			current.blankOwner = c == ' ' || c == '\t'
+			lineStart = false
+		}
+
+		switch {
+		case c == '\n':
+			line++
+			i++
+			if depth == 0 {
+				flush()
+				lineStart = true
+			}
+		case c == ' ' || c == '\t' || c == '\r':
+			i++
+		case c == ';':
+			for i < len(text) && text[i] != '\n' {
+				i++
+			}
+		case c == '(':
+			if depth == 0 {
+				openLine = line
+			}
+			depth++
+			i++
+		case c == ')':
+			if depth == 0 {
+				return nil, &zoneFileError{Line: line, Msg: "unbalanced closing parenthesis"}
+			}
+			depth--
+			i++
+		case c == '"':
+			start := line
+			var b strings.Builder
+			i++
+			for {
+				if i >= len(text) || text[i] == '\n' {
+					return nil, &zoneFileError{Line: start, Msg: "unterminated quoted string"}
+				}
+				if text[i] == '"' {
+					i++
+					break
+				}
+				if text[i] == '\\' && i+1 < len(text) {
+					b.WriteByte(text[i])
+					i++
+				}
+				b.WriteByte(text[i])
+				i++
+			}
+			current.tokens = append(current.tokens, zoneToken{text: b.String(), quoted: true})
+		default:
+			start := i
+			inQuote := false
+			for i < len(text) {
+				ch := text[i]
+				if ch == '\\' && i+1 < len(text) && text[i+1] != '\n' {
+					i += 2
+					continue
+				}
+				if ch == '"' {
+					inQuote = !inQuote
+				} else if ch == '\n' || (!inQuote && (ch == ' ' || ch == '\t' || ch == '\r' || ch == ';' || ch == '(' || ch == ')')) {
+					break
+				}
+				i++
+			}
+			if inQuote {
+				return nil, &zoneFileError{Line: line, Msg: "unterminated quoted string"}
+			}
+			current.tokens = append(current.tokens, zoneToken{text: text[start:i]})
+		}
+	}
+
+	if depth > 0 {
+		return nil, &zoneFileError{Line: openLine, Msg: "unbalanced opening parenthesis"}
+	}
+	flush()
+	return entries, nil
+}
+
+// parseZoneTTL parses a TTL in seconds or in BIND unit notation ("1h30m").
+func parseZoneTTL(s string) (int, bool) {
+	if s == "" {
+		return 0, false
+	}
+	total, n, digits := 0, 0, false
+	for _, c := range strings.ToLower(s) {
+		if c >= '0' && c <= '9' {
+			n = n*10 + int(c-'0')
+			digits = true
+			continue
+		}
+		if !digits {
+			return 0, false
+		}
+		switch c {
+		case 's':
+		case 'm':
+			n *= 60
+		case 'h':
+			n *= 3600
+		case 'd':
+			n *= 86400
+		case 'w':
+			n *= 604800
+		default:
+			return 0, false
+		}
+		total += n
+		n, digits = 0, false
+	}
+	return total + n, true
+}
+
+// splitTTLAndClass consumes the optional TTL and class fields that precede the
+// record type, in either order, and returns the record's TTL and the
+// remaining tokens (type first).
+func splitTTLAndClass(tokens []zoneToken, ttl int) (int, []zoneToken, error) {
+	for len(tokens) > 0 && !tokens[0].quoted {
+		if value, ok := parseZoneTTL(tokens[0].text); ok {
+			ttl = value
+		} else if isZoneClass(tokens[0].text) {
+			if !strings.EqualFold(tokens[0].text, "IN") {
+				return 0, nil, fmt.Errorf("class %s is not supported; only IN records can be managed", strings.ToUpper(tokens[0].text))
+			}
+		} else {
+			break
+		}
+		tokens = tokens[1:]
+	}
+	if len(tokens) == 0 {
+		return 0, nil, fmt.Errorf("missing record type")
+	}
+	return ttl, tokens, nil
+}
+
+func isZoneClass(s string) bool {
+	switch strings.ToUpper(s) {
+	case "IN", "CH", "CS", "HS", "ANY":
+		return true
+	}
+	return strings.HasPrefix(strings.ToUpper(s), "CLASS")
+}
+
+// qualifyName makes name absolute against origin (which ends in a dot).
+func qualifyName(name, origin string) string {
+	switch {
+	case name == "@":
+		return origin
+	case strings.HasSuffix(name, "."):
+		return name
+	}
+	return name + "." + origin
+}
+
+// relativeOwnerName strips zone from an absolute owner name; the zone apex
+// itself becomes "@".
+func relativeOwnerName(owner, zone string) (string, bool) {
+	name := strings.TrimSuffix(owner, ".")
+	lower := strings.ToLower(name)
+	switch {
+	case lower == zone:
+		return "@", true
+	case strings.HasSuffix(lower, "."+zone):
+		return name[:len(name)-len(zone)-1], true
+	}
+	return "", false
+}
+
+// targetName qualifies a hostname in record data and drops the trailing dot,
+// the form the API stores. The root name (".") is kept as is.
+func targetName(name, origin string) string {
+	if name == "." {
+		return name
+	}
+	return strings.TrimSuffix(qualifyName(name, origin), ".")
+}
+
+// splitOwnerLabels sets record.Name from a relative owner name, first moving
+// the leading underscore labels that the API keeps in separate fields — the
+// inverse of zoneOwner.
+func splitOwnerLabels(record *client.DNSRecord, name string) error {
+	var labels []string
+	if name != "@" {
+		labels = strings.Split(name, ".")
+	}
+	rest := func(n int) string {
+		if len(labels) <= n {
+			return "@"
+		}
+		return strings.Join(labels[n:], ".")
+	}
+
+	switch record.Type {
+	case "SRV":
+		if len(labels) < 2 || !strings.HasPrefix(labels[0], "_") || !strings.HasPrefix(labels[1], "_") {
+			return fmt.Errorf("SRV owner %s must start with _service._protocol labels", name)
+		}
+		record.Service, record.Protocol = labels[0], labels[1]
+		record.Name = rest(2)
+	case "TLSA":
+		if len(labels) < 2 || !isPortLabel(labels[0]) || !strings.HasPrefix(labels[1], "_") {
+			return fmt.Errorf("TLSA owner %s must start with _port._protocol labels", name)
+		}
+		record.Port = client.NewStringPortValue(labels[0])
+		record.Protocol = labels[1]
+		record.Name = rest(2)
+	case "HTTPS", "SVCB":
+		// A leading "*" is a wildcard owner unless a scheme label follows it.
+		hasPort := len(labels) > 0 && isPortLabel(labels[0])
+		if hasPort && labels[0] == "*" {
+			hasPort = len(labels) > 1 && strings.HasPrefix(labels[1], "_")
+		}
+		if hasPort {
+			record.Port = client.NewStringPortValue(labels[0])
+			n := 1
+			if len(labels) > 1 && strings.HasPrefix(labels[1], "_") {
+				record.Scheme = labels[1]
+				n = 2
+			}
+			record.Name = rest(n)
+			return nil
+		}
+		record.Name = name
+	default:
+		record.Name = name
+	}
+	return nil
+}
+
+// isPortLabel reports whether a label is a port label: "*" or "_" followed by
+// digits.
+func isPortLabel(label string) bool {
+	if label == "*" {
+		return true
+	}
+	digits, ok := strings.CutPrefix(label, "_")
+	if !ok || digits == "" {
+		return false
+	}
+	_, err := strconv.Atoi(digits)
+	return err == nil
+}
+
+// parseZoneRData fills the type-specific fields of record from its RDATA
+// tokens — the inverse of zoneRData.
+func parseZoneRData(record *client.DNSRecord, rdata []zoneToken, origin string) error {
+	want := func(n int, layout string) error {
+		if len(rdata) != n {
+			return fmt.Errorf("%s record data must be %q, got %d field(s)", record.Type, layout, len(rdata))
+		}
+		return nil
+	}
+	number := func(token zoneToken, field string) (*int, error) {
+		n, err := strconv.Atoi(token.text)
+		if err != nil || token.quoted {
+			return nil, fmt.Errorf("%s record %s must be a number, got %q", record.Type, field, token.text)
+		}
+		return &n, nil
+	}
+
+	var err error
+	switch record.Type {
+	case "A", "AAAA":
+		if err = want(1, "address"); err == nil {
+			record.Address = rdata[0].text
+		}
+	case "ALIAS":
+		if err = want(1, "target"); err == nil {
+			record.AliasName = targetName(rdata[0].text, origin)
+		}
+	case "CAA":
+		if err = want(3, "flag tag value"); err != nil {
+			return err
+		}
+		if record.Flag, err = number(rdata[0], "flag"); err != nil {
+			return err
+		}
+		record.Tag = rdata[1].text
+		record.Value = unescapeCharacterString(rdata[2].text)
+	case "CNAME":
+		if err = want(1, "target"); err == nil {
+			record.CName = targetName(rdata[0].text, origin)
+		}
+	case "HTTPS", "SVCB":
+		if len(rdata) < 2 {
+			return fmt.Errorf("%s record data must be %q, got %d field(s)", record.Type, "priority target [params...]", len(rdata))
+		}
+		if record.SvcPriority, err = number(rdata[0], "priority"); err != nil {
+			return err
+		}
+		record.TargetName = targetName(rdata[1].text, origin)
+		params := make([]string, 0, len(rdata)-2)
+		for _, token := range rdata[2:] {
+			params = append(params, token.text)
+		}
+		record.SvcParams = strings.Join(params, " ")
+	case "MX":
+		if err = want(2, "preference exchange"); err != nil {
+			return err
+		}
+		if record.Preference, err = number(rdata[0], "preference"); err != nil {
+			return err
+		}
+		record.Exchange = targetName(rdata[1].text, origin)
+	case "NS":
+		if err = want(1, "nameserver"); err == nil {
+			record.Nameserver = targetName(rdata[0].text, origin)
+		}
+	case "PTR":
+		if err = want(1, "pointer"); err == nil {
+			record.Pointer = targetName(rdata[0].text, origin)
+		}
+	case "SRV":
+		if err = want(4, "priority weight port target"); err != nil {
+			return err
+		}
+		if record.Priority, err = number(rdata[0], "priority"); err != nil {
+			return err
+		}
+		if record.Weight, err = number(rdata[1], "weight"); err != nil {
+			return err
+		}
+		port, err := number(rdata[2], "port")
+		if err != nil {
+			return err
+		}
+		record.Port = client.NewIntPortValue(*port)
+		record.Target = targetName(rdata[3].text, origin)
+	case "TLSA":
+		if len(rdata) < 4 {
+			return fmt.Errorf("TLSA record data must be %q, got %d field(s)", "usage selector matching data", len(rdata))
+		}
+		if record.Usage, err = number(rdata[0], "usage"); err != nil {
+			return err
+		}
+		if record.Selector, err = number(rdata[1], "selector"); err != nil {
+			return err
+		}
+		if record.Matching, err = number(rdata[2], "matching type"); err != nil {
+			return err
+		}
+		var data strings.Builder
+		for _, token := range rdata[3:] {
+			data.WriteString(token.text)
+		}
+		record.AssociationData = data.String()
+	case "TXT":
+		if len(rdata) == 0 {
+			return fmt.Errorf("TXT record needs at least one character-string")
+		}
+		var value strings.Builder
+		for _, token := range rdata {
+			value.WriteString(unescapeCharacterString(token.text))
+		}
+		record.Value = value.String()
+	}
+	return err
+}
+
+// unescapeCharacterString resolves the \X and \DDD escapes of an RFC 1035
+// <character-string> — the inverse of quoteCharacterString.
+func unescapeCharacterString(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i+1 >= len(s) {
+			b.WriteByte(s[i])
+			continue
+		}
+		if i+3 < len(s) && isDigits(s[i+1:i+4]) {
+			if n, err := strconv.Atoi(s[i+1 : i+4]); err == nil && n <= 255 {
+				b.WriteByte(byte(n))
+				i += 3
+				continue
+			}
+		}
+		b.WriteByte(s[i+1])
+		i++
+	}
+	return b.String()
+}
+
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
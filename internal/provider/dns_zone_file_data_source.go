@@ -0,0 +1,112 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/namecheap/go-spaceship-sdk/client"
+)
+
+// One zone read: a throttling window plus a minute of slack. See
+// internal/docs/rate-limits.md.
+const dnsZoneFileReadTimeout = rateLimitWindow + time.Minute
+
+func NewDNSZoneFileDataSource() datasource.DataSource {
+	return &dnsZoneFileDataSource{}
+}
+
+type dnsZoneFileDataSource struct {
+	client *client.Client
+}
+
+type dnsZoneFileDataSourceModel struct {
+	Domain      types.String   `tfsdk:"domain"`
+	Content     types.String   `tfsdk:"content"`
+	RecordCount types.Int64    `tfsdk:"record_count"`
+	Timeouts    timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (d *dnsZoneFileDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dns_zone_file"
+}
+
+func (d *dnsZoneFileDataSource) Schema(ctx context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Exports the custom DNS records of a Spaceship-managed domain as an RFC 1035 (BIND) zone file. Records owned by Spaceship features (e.g. URL redirect, personal nameservers) are not part of the export.",
+		Attributes: map[string]schema.Attribute{
+			"domain": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The domain whose zone to export (for example `example.com`).",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"content": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The zone file, starting with `$ORIGIN` and `$TTL` directives. Owner names are relative to the domain and targets are fully qualified. Records are sorted, so the content only changes when the zone does.",
+			},
+			"record_count": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Number of records in the zone file.",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx),
+		},
+	}
+}
+
+func (d *dnsZoneFileDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	pd, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected provider data type", fmt.Sprintf("Expected *providerData, got %T", req.ProviderData))
+		return
+	}
+
+	d.client = pd.Client
+}
+
+func (d *dnsZoneFileDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data dnsZoneFileDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if d.client == nil {
+		resp.Diagnostics.AddError("Unconfigured provider", "The Spaceship provider was not configured. Please run terraform init or configure the provider block.")
+		return
+	}
+
+	ctx, cancel := operationContext(ctx, data.Timeouts.Read, dnsZoneFileReadTimeout, &resp.Diagnostics)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	domain := data.Domain.ValueString()
+
+	records, err := getDNSRecordsWithRetry(ctx, d.client, domain)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read DNS records", err.Error())
+		return
+	}
+
+	data.Content = types.StringValue(renderZoneFile(domain, records))
+	data.RecordCount = types.Int64Value(int64(len(records)))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
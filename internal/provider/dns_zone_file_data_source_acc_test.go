@@ -0,0 +1,51 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccDNSZoneFile_basic(t *testing.T) {
+	testAccPreCheck(t)
+
+	domain := testAccDomainValue()
+	name := fmt.Sprintf("%s-zonefile", testAccRecordPrefix())
+
+	config := fmt.Sprintf(`
+provider "spaceship" {}
+
+resource "spaceship_dns_record" "test" {
+  domain = %[1]q
+  type   = "TXT"
+  name   = %[2]q
+  ttl    = 600
+  value  = "zone file \"export\""
+}
+
+data "spaceship_dns_zone_file" "test" {
+  domain = spaceship_dns_record.test.domain
+
+  depends_on = [spaceship_dns_record.test]
+}
+`, domain, name)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckDNSRecordAbsent(domain, "TXT", name),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestMatchResourceAttr("data.spaceship_dns_zone_file.test", "content",
+						regexp.MustCompile(`(?m)^\$ORIGIN `+regexp.QuoteMeta(domain)+`\.$`)),
+					resource.TestMatchResourceAttr("data.spaceship_dns_zone_file.test", "content",
+						regexp.MustCompile(`(?m)^`+regexp.QuoteMeta(name)+`\t600\tIN\tTXT\t"zone file \\"export\\""$`)),
+					expectListCountAtLeast("data.spaceship_dns_zone_file.test", "record_count", 1),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,282 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/namecheap/go-spaceship-sdk/client"
+)
+
+func TestRenderZoneFile_AllTypes(t *testing.T) {
+	records := []client.DNSRecord{
+		{Type: "TXT", Name: "@", TTL: 3600, Value: `v=spf1 include:"x" -all`},
+		{Type: "A", Name: "www", TTL: 300, Address: "192.0.2.1"},
+		{Type: "A", Name: "@", TTL: 3600, Address: "192.0.2.10"},
+		{Type: "AAAA", Name: "@", TTL: 3600, Address: "2001:db8::1"},
+		{Type: "ALIAS", Name: "docs", TTL: 3600, AliasName: "origin.example.net"},
+		{Type: "CAA", Name: "@", TTL: 3600, Flag: intPtr(0), Tag: "issue", Value: "letsencrypt.org"},
+		{Type: "CNAME", Name: "blog", TTL: 3600, CName: "hosting.example.net"},
+		{Type: "HTTPS", Name: "@", TTL: 3600, SvcPriority: intPtr(1), TargetName: ".", SvcParams: "alpn=h2,h3", Port: client.NewStringPortValue("_8443"), Scheme: "_https"},
+		{Type: "SVCB", Name: "svc", TTL: 3600, SvcPriority: intPtr(0), TargetName: "pool.example.net"},
+		{Type: "MX", Name: "@", TTL: 3600, Exchange: "mail.example.com", Preference: intPtr(10)},
+		{Type: "NS", Name: "sub", TTL: 3600, Nameserver: "ns1.example.net"},
+		{Type: "PTR", Name: "10", TTL: 3600, Pointer: "host.example.com"},
+		{Type: "SRV", Name: "@", TTL: 3600, Service: "_sip", Protocol: "_tcp", Priority: intPtr(10), Weight: intPtr(5), Port: client.NewIntPortValue(5060), Target: "sip.example.com"},
+		{Type: "TLSA", Name: "mail", TTL: 3600, Port: client.NewStringPortValue("_25"), Protocol: "_tcp", Usage: intPtr(3), Selector: intPtr(1), Matching: intPtr(1), AssociationData: "ab cd ef"},
+	}
+
+	want := strings.Join([]string{
+		"$ORIGIN example.com.",
+		"$TTL 3600",
+		"@\t3600\tIN\tA\t192.0.2.10",
+		"@\t3600\tIN\tAAAA\t2001:db8::1",
+		"@\t3600\tIN\tCAA\t0 issue \"letsencrypt.org\"",
+		"_8443._https\t3600\tIN\tHTTPS\t1 . alpn=h2,h3",
+		"@\t3600\tIN\tMX\t10 mail.example.com.",
+		"_sip._tcp\t3600\tIN\tSRV\t10 5 5060 sip.example.com.",
+		"@\t3600\tIN\tTXT\t\"v=spf1 include:\\\"x\\\" -all\"",
+		"10\t3600\tIN\tPTR\thost.example.com.",
+		"blog\t3600\tIN\tCNAME\thosting.example.net.",
+		"docs\t3600\tIN\tALIAS\torigin.example.net.",
+		"_25._tcp.mail\t3600\tIN\tTLSA\t3 1 1 abcdef",
+		"sub\t3600\tIN\tNS\tns1.example.net.",
+		"svc\t3600\tIN\tSVCB\t0 pool.example.net.",
+		"www\t300\tIN\tA\t192.0.2.1",
+		"",
+	}, "\n")
+
+	if got := renderZoneFile("Example.com", records); got != want {
+		t.Errorf("renderZoneFile mismatch\n got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestRenderZoneFile_Empty(t *testing.T) {
+	want := "$ORIGIN example.com.\n$TTL 3600\n"
+	if got := renderZoneFile("example.com", nil); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestQuoteTXT_SplitsLongValues(t *testing.T) {
+	value := strings.Repeat("a", txtChunkSize) + "bc"
+	want := `"` + strings.Repeat("a", txtChunkSize) + `" "bc"`
+	if got := quoteTXT(value); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestQuoteCharacterString_EscapesNonPrintable(t *testing.T) {
+	if got, want := quoteCharacterString("a\\b\tcé"), `"a\\b\009c\195\169"`; got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
+
+func TestParseZoneFile_Syntax(t *testing.T) {
+	zone := strings.Join([]string{
+		"; exported from another host",
+		"$ORIGIN example.com.",
+		"$TTL 1h",
+		"@\tIN\tSOA\tns1.other.net. hostmaster.example.com. ( 1 7200 900 1209600 300 )",
+		"@\t\tIN\tA\t192.0.2.10 ; apex",
+		"\t\t\tMX\t10 mail",
+		"www.example.com.\t300\tCNAME\t@",
+		"txt\tIN 600\tTXT\t( \"v=spf1 \\\"a\\\"\"",
+		"\t\t\"\\059 -all\" )",
+		"$ORIGIN sub.example.com.",
+		"_sip._tcp\tSRV\t10 5 5060 sip.example.net.",
+		"_443._tcp.mail\tTLSA\t3 1 1 AB CD",
+		"*._https\tHTTPS\t1 . alpn=\"h2,h3\" port=443",
+		"*\tSVCB\t0 pool",
+	}, "\n")
+
+	got, err := parseZoneFile(zone, "Example.com")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	want := []zoneFileRecord{
+		{Line: 5, Record: client.DNSRecord{Type: "A", Name: "@", TTL: 3600, Address: "192.0.2.10"}},
+		{Line: 6, Record: client.DNSRecord{Type: "MX", Name: "@", TTL: 3600, Preference: intPtr(10), Exchange: "mail.example.com"}},
+		{Line: 7, Record: client.DNSRecord{Type: "CNAME", Name: "www", TTL: 300, CName: "example.com"}},
+		{Line: 8, Record: client.DNSRecord{Type: "TXT", Name: "txt", TTL: 600, Value: `v=spf1 "a"; -all`}},
+		{Line: 11, Record: client.DNSRecord{Type: "SRV", Name: "sub", TTL: 3600, Service: "_sip", Protocol: "_tcp", Priority: intPtr(10), Weight: intPtr(5), Port: client.NewIntPortValue(5060), Target: "sip.example.net"}},
+		{Line: 12, Record: client.DNSRecord{Type: "TLSA", Name: "mail.sub", TTL: 3600, Port: client.NewStringPortValue("_443"), Protocol: "_tcp", Usage: intPtr(3), Selector: intPtr(1), Matching: intPtr(1), AssociationData: "ABCD"}},
+		{Line: 13, Record: client.DNSRecord{Type: "HTTPS", Name: "sub", TTL: 3600, Port: client.NewStringPortValue("*"), Scheme: "_https", SvcPriority: intPtr(1), TargetName: ".", SvcParams: `alpn="h2,h3" port=443`}},
+		{Line: 14, Record: client.DNSRecord{Type: "SVCB", Name: "*.sub", TTL: 3600, SvcPriority: intPtr(0), TargetName: "pool.sub.example.com"}},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseZoneFile mismatch\n got: %+v\nwant: %+v", got, want)
+	}
+}
+
+// Rendering and parsing are inverses, so an exported zone can be fed straight
+// back into spaceship_dns_records.zone_file.
+func TestParseZoneFile_RoundTripsRenderedZone(t *testing.T) {
+	records := []client.DNSRecord{
+		{Type: "A", Name: "@", TTL: 3600, Address: "192.0.2.10"},
+		{Type: "ALIAS", Name: "docs", TTL: 3600, AliasName: "origin.example.net"},
+		{Type: "CAA", Name: "@", TTL: 3600, Flag: intPtr(128), Tag: "issue", Value: "ca.example.net; policy=\"x\""},
+		{Type: "HTTPS", Name: "@", TTL: 3600, SvcPriority: intPtr(1), TargetName: ".", SvcParams: "alpn=h2,h3", Port: client.NewStringPortValue("_8443"), Scheme: "_https"},
+		{Type: "NS", Name: "sub", TTL: 3600, Nameserver: "ns1.example.net"},
+		{Type: "PTR", Name: "10", TTL: 3600, Pointer: "host.example.com"},
+		{Type: "SRV", Name: "voice", TTL: 600, Service: "_sip", Protocol: "_udp", Priority: intPtr(0), Weight: intPtr(0), Port: client.NewIntPortValue(5060), Target: "sip.example.com"},
+		{Type: "TLSA", Name: "@", TTL: 3600, Port: client.NewStringPortValue("_25"), Protocol: "_tcp", Usage: intPtr(3), Selector: intPtr(1), Matching: intPtr(1), AssociationData: "abcdef"},
+		{Type: "TXT", Name: "long", TTL: 3600, Value: strings.Repeat("x", txtChunkSize+10) + "\tend"},
+	}
+
+	rendered := renderZoneFile("example.com", records)
+	parsed, err := parseZoneFile(rendered, "example.com")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	got := make([]client.DNSRecord, len(parsed))
+	for i, entry := range parsed {
+		got[i] = entry.Record
+	}
+	if again := renderZoneFile("example.com", got); again != rendered {
+		t.Errorf("round trip changed the zone\n got:\n%s\nwant:\n%s", again, rendered)
+	}
+}
+
+func TestParseZoneFile_ReportsEveryBadLine(t *testing.T) {
+	zone := strings.Join([]string{
+		"www\tA\t192.0.2.1",
+		"www\tSSHFP\t1 1 abcdef",
+		"ftp.other.com.\tA\t192.0.2.2",
+		"chaos\tCH\tTXT\t\"x\"",
+		"$INCLUDE other.zone",
+		"mail\tMX\tmail.example.com.",
+		"_sip\tSRV\t1 1 5060 sip",
+	}, "\n")
+
+	records, err := parseZoneFile(zone, "example.com")
+	var errs zoneFileErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("expected zoneFileErrors, got %v", err)
+	}
+	if len(records) != 1 {
+		t.Errorf("expected the valid record to still be parsed, got %d records", len(records))
+	}
+
+	wantLines := []int{2, 3, 4, 5, 6, 7}
+	if len(errs) != len(wantLines) {
+		t.Fatalf("expected %d errors, got %d: %s", len(wantLines), len(errs), err)
+	}
+	for i, line := range wantLines {
+		if errs[i].Line != line {
+			t.Errorf("error %d: expected line %d, got %d (%s)", i, line, errs[i].Line, errs[i].Msg)
+		}
+	}
+	if !strings.Contains(errs[0].Msg, "SSHFP is not supported") {
+		t.Errorf("unexpected message for unsupported type: %s", errs[0].Msg)
+	}
+}
+
+func TestParseZoneFile_SyntaxErrors(t *testing.T) {
+	cases := map[string]struct {
+		zone string
+		line int
+	}{
+		"unterminated quote":  {zone: "a\tA\t192.0.2.1\ntxt\tTXT\t\"open\n", line: 2},
+		"unclosed paren":      {zone: "a\tA\t192.0.2.1\nmx\tMX\t( 10\n mail.example.com.\n", line: 2},
+		"stray closing paren": {zone: "a\tA\t192.0.2.1 )\n", line: 1},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := parseZoneFile(tc.zone, "example.com")
+			var lineErr *zoneFileError
+			if !errors.As(err, &lineErr) {
+				t.Fatalf("expected *zoneFileError, got %v", err)
+			}
+			if lineErr.Line != tc.line {
+				t.Errorf("expected line %d, got %d", tc.line, lineErr.Line)
+			}
+		})
+	}
+}
+
+func TestParseZoneTTL(t *testing.T) {
+	cases := map[string]int{"300": 300, "1h": 3600, "1h30m": 5400, "2D": 172800, "1w": 604800, "90s": 90}
+	for input, want := range cases {
+		if got, ok := parseZoneTTL(input); !ok || got != want {
+			t.Errorf("parseZoneTTL(%q) = %d, %v; want %d", input, got, ok, want)
+		}
+	}
+	for _, input := range []string{"", "h", "IN", "A", "1x"} {
+		if _, ok := parseZoneTTL(input); ok {
+			t.Errorf("parseZoneTTL(%q) should fail", input)
+		}
+	}
+}
+
+func TestZoneFileToDNSRecords_ValidatesRecords(t *testing.T) {
+	zone := strings.Join([]string{
+		"www\t300\tA\t192.0.2.1",
+		"WWW\t600\tA\t192.0.2.1",
+		"low\t30\tA\t192.0.2.2",
+		"bad\tA\tnot-an-ip",
+		"ok\tTXT\t\"hello\"",
+	}, "\n")
+
+	records, diags := zoneFileToDNSRecords(context.Background(), zone, "example.com", path.Root("zone_file"))
+	if len(records) != 2 {
+		t.Errorf("expected 2 valid records, got %d", len(records))
+	}
+
+	wantDetails := []string{"Line 2 duplicates line 1", "Line 3: ttl", "Line 4:"}
+	if diags.ErrorsCount() != len(wantDetails) {
+		t.Fatalf("expected %d errors, got %d: %v", len(wantDetails), diags.ErrorsCount(), diags)
+	}
+	for i, d := range diags.Errors() {
+		if !strings.HasPrefix(d.Detail(), wantDetails[i]) {
+			t.Errorf("error %d: expected detail starting %q, got %q", i, wantDetails[i], d.Detail())
+		}
+	}
+}
+
+// The validator reports only errors: ModifyPlan parses the zone file again and
+// emits the warnings, so passing them here too would duplicate them.
+func TestZoneFileValidator_ReportsOnlyErrors(t *testing.T) {
+	ctx := context.Background()
+
+	state := tfsdk.State{Schema: dnsRecordsTestSchema(t)}
+	state.Raw = tftypes.NewValue(state.Schema.Type().TerraformType(ctx), nil)
+	model := dnsRecordsTestModel(t, "example.com")
+	model.Records = types.ListNull(dnsRecordObjectType)
+	if diags := state.Set(ctx, &model); diags.HasError() {
+		t.Fatalf("state.Set: %v", diags)
+	}
+	config := tfsdk.Config{Schema: state.Schema, Raw: state.Raw}
+
+	tests := []struct {
+		zone       string
+		wantErrors int
+	}{
+		{zone: "www\t300\tA\t192.0.2.1"},
+		{zone: "www\t300\tA\t192.0.2.1\nbad\tA\tnot-an-ip", wantErrors: 1},
+	}
+	for _, tt := range tests {
+		req := validator.StringRequest{Path: path.Root("zone_file"), Config: config, ConfigValue: types.StringValue(tt.zone)}
+		resp := &validator.StringResponse{}
+		zoneFileValidator{}.ValidateString(ctx, req, resp)
+
+		if resp.Diagnostics.ErrorsCount() != tt.wantErrors {
+			t.Errorf("%q: got %d errors, want %d: %v", tt.zone, resp.Diagnostics.ErrorsCount(), tt.wantErrors, resp.Diagnostics)
+		}
+		if resp.Diagnostics.WarningsCount() != 0 {
+			t.Errorf("%q: got warnings from the validator: %v", tt.zone, resp.Diagnostics.Warnings())
+		}
+	}
+}
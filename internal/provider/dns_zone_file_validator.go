@@ -0,0 +1,144 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/namecheap/go-spaceship-sdk/client"
+	clientrecords "github.com/namecheap/go-spaceship-sdk/client/records"
+)
+
+// zoneFileValidator checks a spaceship_dns_records zone_file at plan time with
+// the same rules a records list gets: the schema's name and TTL constraints,
+// recordTypeObjectValidators, and duplicateRecordsValidator's identity rule.
+// It needs the domain to resolve owner names, so it defers to ModifyPlan while
+// domain is unknown. ModifyPlan parses the zone file again to fill records and
+// reports the warnings there, so the validator keeps only errors; otherwise
+// every warning would show twice in the plan output.
+type zoneFileValidator struct{}
+
+var _ validator.String = zoneFileValidator{}
+
+func (v zoneFileValidator) Description(_ context.Context) string {
+	return "must be an RFC 1035 zone file of records the Spaceship API supports"
+}
+
+func (v zoneFileValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v zoneFileValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	var domain types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("domain"), &domain)...)
+	if resp.Diagnostics.HasError() || domain.IsNull() || domain.IsUnknown() {
+		return
+	}
+
+	_, diags := zoneFileToDNSRecords(ctx, req.ConfigValue.ValueString(), domain.ValueString(), req.Path)
+	resp.Diagnostics.Append(diags.Errors()...)
+}
+
+// zoneFileToDNSRecords parses a zone file and validates every record. All
+// diagnostics are reported on attrPath (the zone_file attribute has no nested
+// paths to point at), with the offending line in the detail.
+func zoneFileToDNSRecords(ctx context.Context, text, domain string, attrPath path.Path) ([]client.DNSRecord, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	parsed, err := parseZoneFile(text, domain)
+	if err != nil {
+		var lineErrs zoneFileErrors
+		if !errors.As(err, &lineErrs) {
+			diags.AddAttributeError(attrPath, "Invalid Zone File", err.Error())
+			return nil, diags
+		}
+		for _, e := range lineErrs {
+			diags.AddAttributeError(attrPath, "Invalid Zone File", fmt.Sprintf("Line %d: %s", e.Line, e.Msg))
+		}
+		return nil, diags
+	}
+
+	records := make([]client.DNSRecord, 0, len(parsed))
+	firstLineByKey := make(map[string]int, len(parsed))
+	for _, entry := range parsed {
+		record := entry.Record
+		recordDiags := validateZoneFileRecord(ctx, record)
+		for _, d := range recordDiags {
+			detail := fmt.Sprintf("Line %d: %s", entry.Line, d.Detail())
+			if d.Severity() == diag.SeverityError {
+				diags.AddAttributeError(attrPath, d.Summary(), detail)
+			} else {
+				diags.AddAttributeWarning(attrPath, d.Summary(), detail)
+			}
+		}
+		if recordDiags.HasError() {
+			continue
+		}
+
+		key := client.RecordKey(record)
+		if firstLine, seen := firstLineByKey[key]; seen {
+			diags.AddAttributeError(
+				attrPath,
+				"Duplicate DNS Record",
+				fmt.Sprintf(
+					"Line %d duplicates line %d. The Spaceship API matches records by type + name + data case-insensitively (TXT values are case-sensitive) and stores only one copy, so the apply can never converge. Remove one of the records.",
+					entry.Line, firstLine,
+				),
+			)
+			continue
+		}
+		firstLineByKey[key] = entry.Line
+		records = append(records, record)
+	}
+
+	return records, diags
+}
+
+// validateZoneFileRecord applies to one parsed record the checks a records
+// list element gets from the schema. Diagnostic details name the offending
+// field, since every diagnostic ends up on the zone_file attribute itself.
+func validateZoneFileRecord(ctx context.Context, record client.DNSRecord) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if err := clientrecords.ValidateName(record.Name); err != nil {
+		diags.AddError("Invalid Record Name", fmt.Sprintf("name %q %s", record.Name, err))
+	}
+	if err := clientrecords.ValidateTTL(record.TTL); err != nil {
+		diags.AddError("Invalid Record TTL", fmt.Sprintf("ttl %s", err))
+	}
+
+	var model dnsRecordModel
+	hydrateRecordModel(&model, record)
+	obj, objDiags := types.ObjectValueFrom(ctx, dnsRecordObjectType.AttrTypes, model)
+	if objDiags.HasError() {
+		diags.Append(objDiags...)
+		return diags
+	}
+
+	for _, v := range recordTypeObjectValidators() {
+		objResp := &validator.ObjectResponse{}
+		v.ValidateObject(ctx, validator.ObjectRequest{Path: path.Empty(), ConfigValue: obj}, objResp)
+		for _, d := range objResp.Diagnostics {
+			detail := d.Detail()
+			if withPath, ok := d.(diag.DiagnosticWithPath); ok && !withPath.Path().Equal(path.Empty()) {
+				detail = fmt.Sprintf("%s: %s", withPath.Path(), detail)
+			}
+			if d.Severity() == diag.SeverityError {
+				diags.AddError(d.Summary(), detail)
+			} else {
+				diags.AddWarning(d.Summary(), detail)
+			}
+		}
+	}
+
+	return diags
+}
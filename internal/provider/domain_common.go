@@ -37,6 +37,14 @@ func updateAutoRenewWithRetry(ctx context.Context, c *client.Client, domain stri
 	})
 }
 
+// getDomainListWithRetry fetches every domain in the account. The domain list
+// endpoint is rate limited per user, not per domain.
+func getDomainListWithRetry(ctx context.Context, c *client.Client) (client.DomainList, error) {
+	return withRetryValue(ctx, "read domain list", perUserBucket(c), func() (client.DomainList, error) {
+		return c.GetDomainList(ctx)
+	})
+}
+
 func stringValueOrNull(value string) types.String {
 	if value == "" {
 		return types.StringNull()
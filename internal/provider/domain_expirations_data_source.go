@@ -0,0 +1,206 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/namecheap/go-spaceship-sdk/client"
+)
+
+func NewDomainExpirationsDataSource() datasource.DataSource {
+	return &domainExpirationsDataSource{}
+}
+
+type domainExpirationsDataSource struct {
+	client *client.Client
+}
+
+type domainExpirationsDataSourceModel struct {
+	Within        types.String       `tfsdk:"within"`
+	Domains       []domainExpiration `tfsdk:"domains"`
+	AtRiskDomains []string           `tfsdk:"at_risk_domains"`
+	Timeouts      timeouts.Value     `tfsdk:"timeouts"`
+}
+
+type domainExpiration struct {
+	Name            types.String `tfsdk:"name"`
+	ExpirationDate  types.String `tfsdk:"expiration_date"`
+	DaysRemaining   types.Int64  `tfsdk:"days_remaining"`
+	AutoRenew       types.Bool   `tfsdk:"auto_renew"`
+	LifecycleStatus types.String `tfsdk:"lifecycle_status"`
+	Suspensions     []suspension `tfsdk:"suspensions"`
+	AtRisk          types.Bool   `tfsdk:"at_risk"`
+}
+
+func (d *domainExpirationsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_domain_expirations"
+}
+
+func (d *domainExpirationsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data domainExpirationsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if d.client == nil {
+		resp.Diagnostics.AddError("Unconfigured provider", "The Spaceship provider was not configured. Please run terraform init or configure the provider block.")
+		return
+	}
+
+	// The schema validator has already rejected anything that is not a
+	// positive duration.
+	within, err := time.ParseDuration(data.Within.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid duration", err.Error())
+		return
+	}
+
+	ctx, cancel := operationContext(ctx, data.Timeouts.Read, domainReadTimeout, &resp.Diagnostics)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	response, err := getDomainListWithRetry(ctx, d.client)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read domain list", err.Error())
+		return
+	}
+
+	data.Domains, data.AtRiskDomains, err = buildDomainExpirations(response.Items, within, time.Now())
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read domain expirations", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// buildDomainExpirations returns the domains that expire within the window
+// from now, soonest first, along with the names of those at risk of lapsing.
+func buildDomainExpirations(items []client.DomainInfo, within time.Duration, now time.Time) ([]domainExpiration, []string, error) {
+	expiring, err := filterDomains(items, domainFilter{ExpiringWithin: within}, now)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := sortDomains(expiring, "expiration_date"); err != nil {
+		return nil, nil, err
+	}
+
+	domains := []domainExpiration{}
+	atRisk := []string{}
+	for _, info := range expiring {
+		// filterDomains has already parsed the date successfully.
+		expiration, err := parseDomainDate(info.ExpirationDate)
+		if err != nil {
+			return nil, nil, fmt.Errorf("domain %s: %w", info.Name, err)
+		}
+
+		risky := domainAtRisk(info)
+		if risky {
+			atRisk = append(atRisk, info.Name)
+		}
+
+		domains = append(domains, domainExpiration{
+			Name:            types.StringValue(info.Name),
+			ExpirationDate:  types.StringValue(info.ExpirationDate),
+			DaysRemaining:   types.Int64Value(int64(math.Floor(expiration.Sub(now).Hours() / 24))),
+			AutoRenew:       types.BoolValue(info.AutoRenew),
+			LifecycleStatus: types.StringValue(info.LifecycleStatus),
+			Suspensions:     flattenSuspensions(info.Suspensions),
+			AtRisk:          types.BoolValue(risky),
+		})
+	}
+	return domains, atRisk, nil
+}
+
+// domainAtRisk reports whether an expiring domain will not renew on its own:
+// auto-renew is off, a suspension can block the renewal, or the domain has
+// already passed its expiration date without renewing.
+func domainAtRisk(info client.DomainInfo) bool {
+	switch info.LifecycleStatus {
+	case "grace1", "grace2", "redemption":
+		return true
+	}
+	return !info.AutoRenew || len(info.Suspensions) > 0
+}
+
+func (d *domainExpirationsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reports the domains in the Spaceship account that expire within a given window, soonest first, and flags those at risk of lapsing. Intended for `check` blocks that warn before a domain expires without auto-renew.",
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx),
+		},
+		Attributes: map[string]schema.Attribute{
+			"within": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "How far ahead to look, as a duration such as `720h` for 30 days. Domains already past their expiration date (in grace or redemption) are always included.",
+				Validators: []validator.String{
+					positiveDurationValidator(),
+				},
+			},
+			"at_risk_domains": schema.ListAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Names of the `domains` entries with `at_risk` set, soonest expiration first.",
+			},
+			"domains": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Domains expiring within the window, ordered by expiration date.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Computed:    true,
+							Description: "Domain name in ASCII format (A-label).",
+						},
+						"expiration_date": schema.StringAttribute{
+							Computed:    true,
+							Description: "Date and time when the domain registration expires.",
+						},
+						"days_remaining": schema.Int64Attribute{
+							Computed:    true,
+							Description: "Whole days until the expiration date; negative once it has passed.",
+						},
+						"auto_renew": schema.BoolAttribute{
+							Computed:    true,
+							Description: "Whether the auto-renew option is enabled.",
+						},
+						"lifecycle_status": schema.StringAttribute{
+							Computed:    true,
+							Description: "Lifecycle phase. One of creating, registered, grace1, grace2, redemption.",
+						},
+						"suspensions": domainAttributes()["suspensions"],
+						"at_risk": schema.BoolAttribute{
+							Computed:            true,
+							MarkdownDescription: "Whether the domain will not renew on its own: auto-renew is off, it is suspended, or it is already in `grace1`, `grace2` or `redemption`.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *domainExpirationsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	pd, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected provider data type", fmt.Sprintf("Expected *providerData, got %T", req.ProviderData))
+		return
+	}
+
+	d.client = pd.Client
+}
@@ -0,0 +1,51 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+const domainExpirationsDataSourceName = "data.spaceship_domain_expirations.this"
+
+func TestAccDomainExpirationsDataSource_basic(t *testing.T) {
+	domainName := testAccDomainValue()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// A registration lasts at most ten years, so this window covers
+				// every domain in the account.
+				Config: `
+provider "spaceship" {}
+
+data "spaceship_domain_expirations" "this" {
+  within = "87840h"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					expectListCountAtLeast(domainExpirationsDataSourceName, "domains.#", 1),
+					resource.TestCheckTypeSetElemNestedAttrs(domainExpirationsDataSourceName, "domains.*", map[string]string{
+						"name": domainName,
+					}),
+					resource.TestCheckResourceAttrSet(domainExpirationsDataSourceName, "domains.0.expiration_date"),
+					resource.TestCheckResourceAttrSet(domainExpirationsDataSourceName, "domains.0.days_remaining"),
+					resource.TestCheckResourceAttrSet(domainExpirationsDataSourceName, "domains.0.at_risk"),
+				),
+			},
+			{
+				Config: `
+provider "spaceship" {}
+
+data "spaceship_domain_expirations" "this" {
+  within = "30d"
+}
+`,
+				ExpectError: regexp.MustCompile("Invalid duration"),
+			},
+		},
+	})
+}
@@ -0,0 +1,67 @@
+package provider
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/namecheap/go-spaceship-sdk/client"
+)
+
+func TestBuildDomainExpirations(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	items := []client.DomainInfo{
+		{Name: "later.com", AutoRenew: true, LifecycleStatus: "registered", ExpirationDate: "2027-06-01T00:00:00Z"},
+		{Name: "safe.com", AutoRenew: true, LifecycleStatus: "registered", ExpirationDate: "2026-11-01T12:00:00Z"},
+		{Name: "manual.com", AutoRenew: false, LifecycleStatus: "registered", ExpirationDate: "2026-10-20T12:00:00Z"},
+		{Name: "lapsed.com", AutoRenew: true, LifecycleStatus: "grace1", ExpirationDate: "2026-10-10T00:00:00Z"},
+		{Name: "held.com", AutoRenew: true, LifecycleStatus: "registered", ExpirationDate: "2026-10-30T12:00:00Z",
+			Suspensions: []client.ReasonCode{{ReasonCode: "paymentPending"}}},
+		{Name: "new.com", LifecycleStatus: "creating"},
+	}
+
+	domains, atRisk, err := buildDomainExpirations(items, 30*24*time.Hour, now)
+	if err != nil {
+		t.Fatalf("buildDomainExpirations: %v", err)
+	}
+
+	want := []struct {
+		name   string
+		days   int64
+		atRisk bool
+	}{
+		{name: "lapsed.com", days: -7, atRisk: true},
+		{name: "manual.com", days: 4, atRisk: true},
+		{name: "held.com", days: 14, atRisk: true},
+		{name: "safe.com", days: 16, atRisk: false},
+	}
+	if len(domains) != len(want) {
+		t.Fatalf("got %d domains, want %d", len(domains), len(want))
+	}
+	for i, w := range want {
+		got := domains[i]
+		if got.Name.ValueString() != w.name || got.DaysRemaining.ValueInt64() != w.days || got.AtRisk.ValueBool() != w.atRisk {
+			t.Errorf("domains[%d] = %s (%d days, at_risk %v), want %s (%d days, at_risk %v)",
+				i, got.Name.ValueString(), got.DaysRemaining.ValueInt64(), got.AtRisk.ValueBool(), w.name, w.days, w.atRisk)
+		}
+	}
+	if len(domains[2].Suspensions) != 1 || domains[2].Suspensions[0].ReasonCode.ValueString() != "paymentPending" {
+		t.Errorf("held.com suspensions = %v", domains[2].Suspensions)
+	}
+
+	if wantAtRisk := []string{"lapsed.com", "manual.com", "held.com"}; !slices.Equal(atRisk, wantAtRisk) {
+		t.Errorf("at_risk_domains = %v, want %v", atRisk, wantAtRisk)
+	}
+}
+
+func TestBuildDomainExpirations_NoneExpiring(t *testing.T) {
+	items := []client.DomainInfo{{Name: "later.com", AutoRenew: true, ExpirationDate: "2030-01-01"}}
+	domains, atRisk, err := buildDomainExpirations(items, time.Hour, time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("buildDomainExpirations: %v", err)
+	}
+	// Empty rather than nil, so the state holds [] and length() works in HCL.
+	if domains == nil || atRisk == nil || len(domains) != 0 || len(atRisk) != 0 {
+		t.Errorf("got %v and %v, want two empty lists", domains, atRisk)
+	}
+}
@@ -0,0 +1,153 @@
+package provider
+
+import (
+	"cmp"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/namecheap/go-spaceship-sdk/client"
+)
+
+// domainLifecycleStatuses are the lifecycle_status values the API reports.
+var domainLifecycleStatuses = []string{"creating", "registered", "grace1", "grace2", "redemption"}
+
+// domainOrderings are the order_by values spaceship_domain_list accepts. Each
+// sorts ascending, with the domain name breaking ties.
+var domainOrderings = []string{"name", "expiration_date", "registration_date"}
+
+// domainFilter selects domains from a GetDomainList response. The zero value
+// matches every domain; each set field narrows the match further.
+type domainFilter struct {
+	TLD             string
+	NameRegex       *regexp.Regexp
+	LifecycleStatus string
+	AutoRenew       *bool
+	// ExpiringWithin keeps domains whose expiration date is at most this far
+	// in the future, including those already past it (grace, redemption).
+	ExpiringWithin time.Duration
+	HasSuspension  *bool
+}
+
+// matches reports whether info passes every set field of f, with now as the
+// reference for ExpiringWithin. It fails only when ExpiringWithin is set and
+// the expiration date cannot be parsed.
+func (f domainFilter) matches(info client.DomainInfo, now time.Time) (bool, error) {
+	if f.TLD != "" && !domainHasTLD(info.Name, f.TLD) {
+		return false, nil
+	}
+	if f.NameRegex != nil && !f.NameRegex.MatchString(info.Name) {
+		return false, nil
+	}
+	if f.LifecycleStatus != "" && !strings.EqualFold(info.LifecycleStatus, f.LifecycleStatus) {
+		return false, nil
+	}
+	if f.AutoRenew != nil && info.AutoRenew != *f.AutoRenew {
+		return false, nil
+	}
+	if f.HasSuspension != nil && (len(info.Suspensions) > 0) != *f.HasSuspension {
+		return false, nil
+	}
+	if f.ExpiringWithin > 0 {
+		expiring, err := domainExpiresWithin(info, now, f.ExpiringWithin)
+		if err != nil || !expiring {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// filterDomains returns the domains in items that f matches, in their
+// original order.
+func filterDomains(items []client.DomainInfo, f domainFilter, now time.Time) ([]client.DomainInfo, error) {
+	matched := []client.DomainInfo{}
+	for _, info := range items {
+		ok, err := f.matches(info, now)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			matched = append(matched, info)
+		}
+	}
+	return matched, nil
+}
+
+// domainHasTLD reports whether name is under tld, given with or without its
+// leading dot. Multi-label suffixes such as `co.uk` match too; the comparison
+// ignores case.
+func domainHasTLD(name, tld string) bool {
+	suffix := "." + strings.ToLower(strings.TrimPrefix(tld, "."))
+	return strings.HasSuffix(strings.ToLower(name), suffix)
+}
+
+// domainExpiresWithin reports whether the domain expires no later than
+// within from now. A domain without an expiration date (still being
+// created) never does.
+func domainExpiresWithin(info client.DomainInfo, now time.Time, within time.Duration) (bool, error) {
+	if info.ExpirationDate == "" {
+		return false, nil
+	}
+	expiration, err := parseDomainDate(info.ExpirationDate)
+	if err != nil {
+		return false, fmt.Errorf("domain %s: %w", info.Name, err)
+	}
+	return !expiration.After(now.Add(within)), nil
+}
+
+// parseDomainDate parses the registration and expiration dates the API
+// returns: RFC 3339 timestamps, or bare dates read as midnight UTC.
+func parseDomainDate(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("unrecognized date %q", value)
+	}
+	return t, nil
+}
+
+// sortDomains orders items in place by one of domainOrderings, ascending, with
+// the name breaking ties. Dates are compared as parsed times, since
+// parseDomainDate accepts more than one form; a domain without the date
+// (still being created) sorts first.
+func sortDomains(items []client.DomainInfo, orderBy string) error {
+	type keyed struct {
+		info client.DomainInfo
+		date time.Time
+	}
+	sorted := make([]keyed, len(items))
+	for i, info := range items {
+		sorted[i].info = info
+
+		var value string
+		switch orderBy {
+		case "expiration_date":
+			value = info.ExpirationDate
+		case "registration_date":
+			value = info.RegistrationDate
+		}
+		if value == "" {
+			continue
+		}
+		date, err := parseDomainDate(value)
+		if err != nil {
+			return fmt.Errorf("domain %s: %w", info.Name, err)
+		}
+		sorted[i].date = date
+	}
+
+	slices.SortStableFunc(sorted, func(a, b keyed) int {
+		return cmp.Or(
+			a.date.Compare(b.date),
+			cmp.Compare(strings.ToLower(a.info.Name), strings.ToLower(b.info.Name)),
+		)
+	})
+	for i := range sorted {
+		items[i] = sorted[i].info
+	}
+	return nil
+}
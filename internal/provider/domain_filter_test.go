@@ -0,0 +1,137 @@
+package provider
+
+import (
+	"regexp"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/namecheap/go-spaceship-sdk/client"
+)
+
+func testFilterDomains() []client.DomainInfo {
+	return []client.DomainInfo{
+		{Name: "alpha.com", AutoRenew: true, LifecycleStatus: "registered", RegistrationDate: "2020-03-01T00:00:00Z", ExpirationDate: "2026-12-01T00:00:00Z"},
+		{Name: "beta.co.uk", AutoRenew: false, LifecycleStatus: "registered", RegistrationDate: "2019-01-01T00:00:00Z", ExpirationDate: "2026-10-20T00:00:00Z"},
+		{Name: "gamma.com", AutoRenew: false, LifecycleStatus: "grace1", RegistrationDate: "2021-06-01T00:00:00Z", ExpirationDate: "2026-10-01T00:00:00Z",
+			Suspensions: []client.ReasonCode{{ReasonCode: "abuse"}}},
+		{Name: "delta.net", LifecycleStatus: "creating"},
+	}
+}
+
+func TestFilterDomains(t *testing.T) {
+	now := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+	yes, no := true, false
+
+	tests := []struct {
+		name   string
+		filter domainFilter
+		want   []string
+	}{
+		{name: "zero value matches all", want: []string{"alpha.com", "beta.co.uk", "gamma.com", "delta.net"}},
+		{name: "tld", filter: domainFilter{TLD: ".com"}, want: []string{"alpha.com", "gamma.com"}},
+		{name: "multi-label tld", filter: domainFilter{TLD: "co.uk"}, want: []string{"beta.co.uk"}},
+		{name: "name regex", filter: domainFilter{NameRegex: regexp.MustCompile(`^(alpha|delta)\.`)}, want: []string{"alpha.com", "delta.net"}},
+		{name: "lifecycle status", filter: domainFilter{LifecycleStatus: "grace1"}, want: []string{"gamma.com"}},
+		{name: "auto renew off", filter: domainFilter{AutoRenew: &no}, want: []string{"beta.co.uk", "gamma.com", "delta.net"}},
+		{name: "suspended", filter: domainFilter{HasSuspension: &yes}, want: []string{"gamma.com"}},
+		{name: "not suspended", filter: domainFilter{HasSuspension: &no}, want: []string{"alpha.com", "beta.co.uk", "delta.net"}},
+		// Already-expired domains match; a domain without an expiration date does not.
+		{name: "expiring within", filter: domainFilter{ExpiringWithin: 7 * 24 * time.Hour}, want: []string{"beta.co.uk", "gamma.com"}},
+		{name: "combined", filter: domainFilter{ExpiringWithin: 30 * 24 * time.Hour, AutoRenew: &no, TLD: "uk"}, want: []string{"beta.co.uk"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := filterDomains(testFilterDomains(), tt.filter, now)
+			if err != nil {
+				t.Fatalf("filterDomains: %v", err)
+			}
+			var names []string
+			for _, info := range got {
+				names = append(names, info.Name)
+			}
+			if !slices.Equal(names, tt.want) {
+				t.Errorf("names = %v, want %v", names, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilterDomains_UnparseableExpiration(t *testing.T) {
+	items := []client.DomainInfo{{Name: "bad.com", ExpirationDate: "next tuesday"}}
+	if _, err := filterDomains(items, domainFilter{ExpiringWithin: time.Hour}, time.Now()); err == nil {
+		t.Fatal("expected an error for an unparseable expiration date")
+	}
+	// Without expiring_within the date is never parsed.
+	if got, err := filterDomains(items, domainFilter{}, time.Now()); err != nil || len(got) != 1 {
+		t.Fatalf("got %v, %v; want the domain and no error", got, err)
+	}
+}
+
+func TestParseDomainDate(t *testing.T) {
+	for _, value := range []string{"2026-10-20T00:00:00Z", "2026-10-20T00:00:00.000Z", "2026-10-20"} {
+		got, err := parseDomainDate(value)
+		if err != nil {
+			t.Fatalf("parseDomainDate(%q): %v", value, err)
+		}
+		if want := time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC); !got.Equal(want) {
+			t.Errorf("parseDomainDate(%q) = %v, want %v", value, got, want)
+		}
+	}
+}
+
+func TestSortDomains(t *testing.T) {
+	tests := []struct {
+		orderBy string
+		want    []string
+	}{
+		{orderBy: "name", want: []string{"alpha.com", "beta.co.uk", "delta.net", "gamma.com"}},
+		// delta.net has no dates and sorts first.
+		{orderBy: "expiration_date", want: []string{"delta.net", "gamma.com", "beta.co.uk", "alpha.com"}},
+		{orderBy: "registration_date", want: []string{"delta.net", "beta.co.uk", "alpha.com", "gamma.com"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.orderBy, func(t *testing.T) {
+			items := testFilterDomains()
+			if err := sortDomains(items, tt.orderBy); err != nil {
+				t.Fatalf("sortDomains: %v", err)
+			}
+			var names []string
+			for _, info := range items {
+				names = append(names, info.Name)
+			}
+			if !slices.Equal(names, tt.want) {
+				t.Errorf("names = %v, want %v", names, tt.want)
+			}
+		})
+	}
+}
+
+// Dates in different ISO 8601 forms sort by the instant they denote, not as
+// strings: 2026-10-20T01:00:00+05:00 is 2026-10-19T20:00Z, before the others.
+func TestSortDomains_MixedDateForms(t *testing.T) {
+	items := []client.DomainInfo{
+		{Name: "a.com", ExpirationDate: "2026-10-20"},
+		{Name: "b.com", ExpirationDate: "2026-10-20T01:00:00+05:00"},
+		{Name: "c.com", ExpirationDate: "2026-10-19T23:00:00.000Z"},
+	}
+	if err := sortDomains(items, "expiration_date"); err != nil {
+		t.Fatalf("sortDomains: %v", err)
+	}
+	var names []string
+	for _, info := range items {
+		names = append(names, info.Name)
+	}
+	if want := []string{"b.com", "c.com", "a.com"}; !slices.Equal(names, want) {
+		t.Errorf("names = %v, want %v", names, want)
+	}
+}
+
+func TestSortDomains_UnparseableDate(t *testing.T) {
+	items := []client.DomainInfo{{Name: "a.com", ExpirationDate: "soon"}, {Name: "b.com", ExpirationDate: "2026-10-20"}}
+	if err := sortDomains(items, "expiration_date"); err == nil {
+		t.Fatal("expected an error for an unparseable expiration date")
+	}
+}
@@ -3,10 +3,16 @@ package provider
 import (
 	"context"
 	"fmt"
+	"regexp"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
@@ -26,9 +32,47 @@ func (r *domainListDataSource) Metadata(_ context.Context, req datasource.Metada
 }
 
 type domainListDataSourceModel struct {
-	Items    []domainModel  `tfsdk:"items"`
-	Total    types.Int64    `tfsdk:"total"`
-	Timeouts timeouts.Value `tfsdk:"timeouts"`
+	TLD             types.String   `tfsdk:"tld"`
+	NameRegex       types.String   `tfsdk:"name_regex"`
+	LifecycleStatus types.String   `tfsdk:"lifecycle_status"`
+	AutoRenew       types.Bool     `tfsdk:"auto_renew"`
+	ExpiringWithin  types.String   `tfsdk:"expiring_within"`
+	HasSuspension   types.Bool     `tfsdk:"has_suspension"`
+	OrderBy         types.String   `tfsdk:"order_by"`
+	Items           []domainModel  `tfsdk:"items"`
+	Total           types.Int64    `tfsdk:"total"`
+	Timeouts        timeouts.Value `tfsdk:"timeouts"`
+}
+
+// filter converts the configured filter arguments into a domainFilter. The
+// schema validators have already checked the regex and duration, so errors
+// here only guard against a validator being bypassed.
+func (m domainListDataSourceModel) filter() (domainFilter, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	f := domainFilter{
+		TLD:             m.TLD.ValueString(),
+		LifecycleStatus: m.LifecycleStatus.ValueString(),
+		AutoRenew:       m.AutoRenew.ValueBoolPointer(),
+		HasSuspension:   m.HasSuspension.ValueBoolPointer(),
+	}
+
+	if !m.NameRegex.IsNull() {
+		re, err := regexp.Compile(m.NameRegex.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("name_regex"), "Invalid regular expression", err.Error())
+		}
+		f.NameRegex = re
+	}
+
+	if !m.ExpiringWithin.IsNull() {
+		d, err := time.ParseDuration(m.ExpiringWithin.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("expiring_within"), "Invalid duration", err.Error())
+		}
+		f.ExpiringWithin = d
+	}
+
+	return f, diags
 }
 
 func (r *domainListDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -52,10 +96,13 @@ func (r *domainListDataSource) Read(ctx context.Context, req datasource.ReadRequ
 		return
 	}
 
-	// The domain list bucket is per user, not per domain.
-	response, err := withRetryValue(ctx, "read domain list", perUserBucket(r.client), func() (client.DomainList, error) {
-		return r.client.GetDomainList(ctx)
-	})
+	filter, filterDiags := data.filter()
+	resp.Diagnostics.Append(filterDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	response, err := getDomainListWithRetry(ctx, r.client)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to read domain list",
@@ -64,8 +111,24 @@ func (r *domainListDataSource) Read(ctx context.Context, req datasource.ReadRequ
 		return
 	}
 
+	// The API has no server-side filters, so every page is fetched and the
+	// filters and any ordering are applied here.
+	items, err := filterDomains(response.Items, filter, time.Now())
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to filter domain list", err.Error())
+		return
+	}
+	// Without order_by the API's own order is kept, so configs indexing into
+	// items see the same order as before the argument existed.
+	if !data.OrderBy.IsNull() {
+		if err := sortDomains(items, data.OrderBy.ValueString()); err != nil {
+			resp.Diagnostics.AddError("Unable to sort domain list", err.Error())
+			return
+		}
+	}
+
 	data.Items = []domainModel{}
-	for _, item := range response.Items {
+	for _, item := range items {
 		domainDetails, domainDiags := buildDomainModel(ctx, item)
 		resp.Diagnostics.Append(domainDiags...)
 		if resp.Diagnostics.HasError() {
@@ -83,18 +146,61 @@ func (r *domainListDataSource) Read(ctx context.Context, req datasource.ReadRequ
 
 func (r *domainListDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		MarkdownDescription: "Lists every domain in the Spaceship account, with the same details for each entry as the `spaceship_domain_info` data source.",
+		MarkdownDescription: "Lists the domains in the Spaceship account, with the same details for each entry as the `spaceship_domain_info` data source. Every domain is fetched; the optional filters then narrow `items` and `order_by` sorts it.",
 		Blocks: map[string]schema.Block{
 			"timeouts": timeouts.Block(ctx),
 		},
 		Attributes: map[string]schema.Attribute{
+			"tld": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only list domains under this TLD, with or without the leading dot (for example `com` or `co.uk`).",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"name_regex": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only list domains whose ASCII name matches this RE2 regular expression. The match is unanchored; use `^` and `$` to match the whole name.",
+				Validators: []validator.String{
+					validRegexValidator(),
+				},
+			},
+			"lifecycle_status": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only list domains in this lifecycle phase. One of `creating`, `registered`, `grace1`, `grace2`, `redemption`.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(domainLifecycleStatuses...),
+				},
+			},
+			"auto_renew": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only list domains whose auto-renew setting equals this value.",
+			},
+			"expiring_within": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only list domains that expire within this duration from now, such as `720h` for 30 days. Domains already past their expiration date (in grace or redemption) are included.",
+				Validators: []validator.String{
+					positiveDurationValidator(),
+				},
+			},
+			"has_suspension": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "When `true`, only list suspended domains; when `false`, only domains without suspensions.",
+			},
+			"order_by": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Sort `items` ascending by `name`, `expiration_date` or `registration_date`, with ties broken by name. When unset, `items` keeps the order the API returns.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(domainOrderings...),
+				},
+			},
 			"total": schema.Int64Attribute{
 				Computed:    true,
-				Description: "Total number of domains in the account.",
+				Description: "Total number of domains in the account, before any filter is applied.",
 			},
 			"items": schema.ListNestedAttribute{
 				Computed:    true,
-				Description: "Details of each domain in the account.",
+				Description: "Details of each domain that matches the filters.",
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: domainAttributes(),
 				},
@@ -47,6 +47,56 @@ data "spaceship_domain_list" "this"{}
 	})
 }
 
+func TestAccDatasourceDomainList_filters(t *testing.T) {
+	domainName := testAccDomainValue()
+	// %q below escapes the backslashes QuoteMeta adds, as HCL expects.
+	nameRegex := "^" + regexp.QuoteMeta(domainName) + "$"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "spaceship" {}
+
+data "spaceship_domain_list" "this" {
+  name_regex = %q
+  order_by   = "expiration_date"
+}
+`, nameRegex),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					expectListCountAtLeast(domainListDataSourceName, "total", 1),
+					resource.TestCheckResourceAttr(domainListDataSourceName, "items.#", "1"),
+					resource.TestCheckResourceAttr(domainListDataSourceName, domainAttr(0, "name"), domainName),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+provider "spaceship" {}
+
+data "spaceship_domain_list" "this" {
+  name_regex     = %q
+  has_suspension = true
+  auto_renew     = false
+}
+`, nameRegex),
+				Check: resource.TestCheckResourceAttr(domainListDataSourceName, "items.#", "0"),
+			},
+			{
+				Config: `
+provider "spaceship" {}
+
+data "spaceship_domain_list" "this" {
+  expiring_within = "30d"
+}
+`,
+				ExpectError: regexp.MustCompile("Invalid duration"),
+			},
+		},
+	})
+}
+
 func TestAccDomainListDataSource_Unconfigured(t *testing.T) {
 	cfg := `
 data "spaceship_domain_list" "this" {}
@@ -0,0 +1,71 @@
+package provider
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// readDomainList runs the data source's Read against items with every
+// argument unset except those in args, and returns the names in items.
+func readDomainList(t *testing.T, items []map[string]any, args map[string]tftypes.Value) []string {
+	t.Helper()
+	ctx := context.Background()
+
+	d := &domainListDataSource{client: newListClient(t, 0, items)}
+	schemaResp := &datasource.SchemaResponse{}
+	d.Schema(ctx, datasource.SchemaRequest{}, schemaResp)
+
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	values := map[string]tftypes.Value{}
+	for name, attrType := range objectType.AttributeTypes {
+		values[name] = tftypes.NewValue(attrType, nil)
+	}
+	for name, value := range args {
+		values[name] = value
+	}
+	raw := tftypes.NewValue(objectType, values)
+
+	resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)}}
+	d.Read(ctx, datasource.ReadRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: raw}}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Read: %v", resp.Diagnostics)
+	}
+
+	var state domainListDataSourceModel
+	if diags := resp.State.Get(ctx, &state); diags.HasError() {
+		t.Fatalf("State.Get: %v", diags)
+	}
+	var names []string
+	for _, item := range state.Items {
+		names = append(names, item.Name.ValueString())
+	}
+	return names
+}
+
+func TestDomainListDataSource_OrderBy(t *testing.T) {
+	items := []map[string]any{
+		{"name": "zulu.com", "expirationDate": "2027-01-01T00:00:00Z"},
+		{"name": "alpha.com", "expirationDate": "2028-01-01T00:00:00Z"},
+		{"name": "mike.com", "expirationDate": "2026-12-01T00:00:00Z"},
+	}
+
+	// Unset, the API order is kept so existing items[N] references are stable.
+	if got, want := readDomainList(t, items, nil), []string{"zulu.com", "alpha.com", "mike.com"}; !slices.Equal(got, want) {
+		t.Errorf("unset order_by: names = %v, want %v", got, want)
+	}
+
+	byName := map[string]tftypes.Value{"order_by": tftypes.NewValue(tftypes.String, "name")}
+	if got, want := readDomainList(t, items, byName), []string{"alpha.com", "mike.com", "zulu.com"}; !slices.Equal(got, want) {
+		t.Errorf("order_by = name: names = %v, want %v", got, want)
+	}
+
+	byExpiration := map[string]tftypes.Value{"order_by": tftypes.NewValue(tftypes.String, "expiration_date")}
+	if got, want := readDomainList(t, items, byExpiration), []string{"mike.com", "zulu.com", "alpha.com"}; !slices.Equal(got, want) {
+		t.Errorf("order_by = expiration_date: names = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,153 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/namecheap/go-spaceship-sdk/client"
+)
+
+var (
+	_ list.ListResource              = &domainListResource{}
+	_ list.ListResourceWithConfigure = &domainListResource{}
+)
+
+func NewDomainListResource() list.ListResource {
+	return &domainListResource{}
+}
+
+// domainListResource enumerates the account's domains for `terraform query`,
+// one spaceship_domain per domain, so -generate-config-out can write an import
+// block and configuration for every domain.
+type domainListResource struct {
+	client *client.Client
+}
+
+type domainListConfigModel struct {
+	TLD             types.String `tfsdk:"tld"`
+	LifecycleStatus types.String `tfsdk:"lifecycle_status"`
+}
+
+func (r *domainListResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_domain"
+}
+
+func (r *domainListResource) ListResourceConfigSchema(_ context.Context, _ list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the domains in the Spaceship account as `spaceship_domain` resources, optionally narrowed by TLD or lifecycle status.",
+		Attributes: map[string]schema.Attribute{
+			"tld": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only list domains under this TLD, with or without the leading dot (for example `com` or `co.uk`).",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"lifecycle_status": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only list domains in this lifecycle phase. One of `creating`, `registered`, `grace1`, `grace2`, `redemption`.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(domainLifecycleStatuses...),
+				},
+			},
+		},
+	}
+}
+
+func (r *domainListResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	pd, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected provider data type", fmt.Sprintf("Expected *providerData, got %T", req.ProviderData))
+		return
+	}
+
+	r.client = pd.Client
+}
+
+func (r *domainListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	if r.client == nil {
+		var diags diag.Diagnostics
+		diags.AddError("Unconfigured provider", "The Spaceship provider was not configured. Please ensure the provider block is present.")
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	var config domainListConfigModel
+	diags := req.Config.Get(ctx, &config)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	// As for DNS records, the list is fetched before the stream is returned so
+	// the read deadline covers the API calls only.
+	domains, err := r.listDomainsWithRetry(ctx)
+	if err != nil {
+		diags.AddError("Unable to read domain list", err.Error())
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	filter := domainFilter{
+		TLD:             config.TLD.ValueString(),
+		LifecycleStatus: config.LifecycleStatus.ValueString(),
+	}
+	items, err := filterDomains(domains.Items, filter, time.Now())
+	if err != nil {
+		diags.AddError("Unable to filter domain list", err.Error())
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	timeoutsValue, diags := nullTimeouts(ctx, req)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	stream.Results = func(push func(list.ListResult) bool) {
+		for i, info := range items {
+			if req.Limit > 0 && int64(i) >= req.Limit {
+				return
+			}
+
+			result := req.NewListResult(ctx)
+			result.DisplayName = info.Name
+			result.Diagnostics.Append(result.Identity.Set(ctx, domainIdentityModel{Domain: types.StringValue(info.Name)})...)
+
+			if req.IncludeResource {
+				model := domainResourceModel{
+					Domain:   types.StringValue(info.Name),
+					Timeouts: timeoutsValue,
+				}
+				result.Diagnostics.Append(applyDomainInfo(ctx, &model, info)...)
+				result.Diagnostics.Append(result.Resource.Set(ctx, &model)...)
+			}
+
+			if !push(result) {
+				return
+			}
+		}
+	}
+}
+
+// listDomainsWithRetry reads the whole account, bounded by the default read
+// timeout since a list resource has no timeouts.
+func (r *domainListResource) listDomainsWithRetry(ctx context.Context) (client.DomainList, error) {
+	ctx, cancel := context.WithTimeout(ctx, domainReadTimeout)
+	defer cancel()
+	return getDomainListWithRetry(ctx, r.client)
+}
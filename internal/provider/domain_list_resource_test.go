@@ -0,0 +1,137 @@
+package provider
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// newDomainListRequest builds the request Terraform sends for a
+// spaceship_domain list block with the given tld and lifecycle_status
+// (nil leaves the argument unset).
+func newDomainListRequest(t *testing.T, tld, lifecycleStatus *string, includeResource bool) list.ListRequest {
+	t.Helper()
+	ctx := context.Background()
+
+	r := &domainResource{}
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+	identityResp := &fwresource.IdentitySchemaResponse{}
+	r.IdentitySchema(ctx, fwresource.IdentitySchemaRequest{}, identityResp)
+
+	configResp := &list.ListResourceSchemaResponse{}
+	(&domainListResource{}).ListResourceConfigSchema(ctx, list.ListResourceSchemaRequest{}, configResp)
+	config := tfsdk.Config{
+		Schema: configResp.Schema,
+		Raw: tftypes.NewValue(configResp.Schema.Type().TerraformType(ctx), map[string]tftypes.Value{
+			"tld":              tftypes.NewValue(tftypes.String, tld),
+			"lifecycle_status": tftypes.NewValue(tftypes.String, lifecycleStatus),
+		}),
+	}
+
+	return list.ListRequest{
+		Config:                 config,
+		IncludeResource:        includeResource,
+		ResourceSchema:         schemaResp.Schema,
+		ResourceIdentitySchema: identityResp.IdentitySchema,
+	}
+}
+
+func testDomainListItems() []map[string]any {
+	return []map[string]any{
+		{"name": "example.com", "unicodeName": "example.com", "autoRenew": true, "lifecycleStatus": "registered",
+			"nameservers": map[string]any{"provider": "basic", "hosts": []string{"launch1.spaceship.net", "launch2.spaceship.net"}}},
+		{"name": "example.co.uk", "unicodeName": "example.co.uk", "lifecycleStatus": "registered",
+			"nameservers": map[string]any{"provider": "custom", "hosts": []string{"ns1.example.net", "ns2.example.net"}}},
+		{"name": "lapsed.com", "unicodeName": "lapsed.com", "lifecycleStatus": "grace1",
+			"nameservers": map[string]any{"provider": "basic", "hosts": []string{"launch1.spaceship.net", "launch2.spaceship.net"}}},
+	}
+}
+
+// listDomainNames drains the stream and returns the identity domain of each
+// result, failing on any diagnostic.
+func listDomainNames(t *testing.T, stream *list.ListResultsStream) []string {
+	t.Helper()
+	ctx := context.Background()
+
+	var names []string
+	for result := range stream.Results {
+		if result.Diagnostics.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", result.Diagnostics)
+		}
+		var domain types.String
+		if diags := result.Identity.GetAttribute(ctx, path.Root("domain"), &domain); diags.HasError() {
+			t.Fatalf("identity.GetAttribute: %v", diags)
+		}
+		names = append(names, domain.ValueString())
+	}
+	return names
+}
+
+func TestDomainListResource_List(t *testing.T) {
+	c := newListClient(t, 0, testDomainListItems())
+	r := &domainListResource{client: c}
+	ctx := context.Background()
+
+	stream := &list.ListResultsStream{}
+	r.List(ctx, newDomainListRequest(t, nil, nil, true), stream)
+
+	var names []string
+	for result := range stream.Results {
+		if result.Diagnostics.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", result.Diagnostics)
+		}
+
+		var state domainResourceModel
+		if diags := result.Resource.Get(ctx, &state); diags.HasError() {
+			t.Fatalf("resource.Get: %v", diags)
+		}
+		if state.Domain.ValueString() != result.DisplayName {
+			t.Errorf("resource domain %q does not match display name %q", state.Domain.ValueString(), result.DisplayName)
+		}
+		if state.Nameservers.IsNull() || state.AutoRenew.IsNull() {
+			t.Errorf("expected nameservers and auto_renew to be populated for %s", result.DisplayName)
+		}
+		names = append(names, state.Domain.ValueString())
+	}
+
+	if want := []string{"example.com", "example.co.uk", "lapsed.com"}; !slices.Equal(names, want) {
+		t.Errorf("names = %v, want %v", names, want)
+	}
+}
+
+func TestDomainListResource_ListFilters(t *testing.T) {
+	c := newListClient(t, 0, testDomainListItems())
+	r := &domainListResource{client: c}
+
+	ptr := func(v string) *string { return &v }
+
+	tests := []struct {
+		name            string
+		tld             *string
+		lifecycleStatus *string
+		want            []string
+	}{
+		{name: "tld", tld: ptr("com"), want: []string{"example.com", "lapsed.com"}},
+		{name: "multi-label tld with dot", tld: ptr(".CO.UK"), want: []string{"example.co.uk"}},
+		{name: "lifecycle status", lifecycleStatus: ptr("grace1"), want: []string{"lapsed.com"}},
+		{name: "both", tld: ptr("com"), lifecycleStatus: ptr("registered"), want: []string{"example.com"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stream := &list.ListResultsStream{}
+			r.List(context.Background(), newDomainListRequest(t, tt.tld, tt.lifecycleStatus, false), stream)
+			if got := listDomainNames(t, stream); !slices.Equal(got, tt.want) {
+				t.Errorf("names = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -8,6 +8,9 @@ import (
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
 
 	"github.com/namecheap/go-spaceship-sdk/client"
 )
@@ -46,6 +49,44 @@ resource "spaceship_domain" "test" {
 	})
 }
 
+// TestDomainResource_ImportByIdentity verifies that a domain resource records
+// its domain as the resource identity and can be imported by it.
+func TestDomainResource_ImportByIdentity(t *testing.T) {
+	server := mockDomainAPIReadOnly(t, baseDomainInfo())
+
+	t.Setenv("SPACESHIP_BASE_URL", server.URL+"/v1")
+	t.Setenv("SPACESHIP_API_KEY", "test-key")
+	t.Setenv("SPACESHIP_API_SECRET", "test-secret")
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testMockProviderFactories(),
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_12_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "spaceship" {}
+
+resource "spaceship_domain" "test" {
+  domain = "example.com"
+}
+`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectIdentity("spaceship_domain.test", map[string]knownvalue.Check{
+						"domain": knownvalue.StringExact("example.com"),
+					}),
+				},
+			},
+			{
+				ResourceName:    "spaceship_domain.test",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithResourceIdentity,
+			},
+		},
+	})
+}
+
 // mockDomainAPIReadOnly creates a simple deterministic mock that always
 // returns the same domain info for GET requests. No state mutation.
 func mockDomainAPIReadOnly(t *testing.T, domain client.DomainInfo) *httptest.Server {
@@ -0,0 +1,39 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// positiveDuration is a validator that requires a string to be a positive Go
+// duration such as `720h`.
+type positiveDuration struct{}
+
+func positiveDurationValidator() validator.String {
+	return positiveDuration{}
+}
+
+func (v positiveDuration) Description(_ context.Context) string {
+	return "value must be a positive duration such as 720h"
+}
+
+func (v positiveDuration) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v positiveDuration) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	d, err := time.ParseDuration(req.ConfigValue.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid duration", fmt.Sprintf("%q is not a duration (for example 720h or 90m): %s", req.ConfigValue.ValueString(), err))
+		return
+	}
+	if d <= 0 {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid duration", fmt.Sprintf("%q must be greater than zero.", req.ConfigValue.ValueString()))
+	}
+}
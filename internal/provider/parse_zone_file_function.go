@@ -0,0 +1,132 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/namecheap/go-spaceship-sdk/client"
+
+	"terraform-provider-spaceship/internal/provider/records"
+)
+
+var _ function.Function = &parseZoneFileFunction{}
+
+func NewParseZoneFileFunction() function.Function {
+	return &parseZoneFileFunction{}
+}
+
+// parseZoneFileFunction exposes parseZoneFile to HCL, so a zone can be
+// filtered or merged before it reaches spaceship_dns_records.records. Unlike
+// the zone_file attribute it does not validate record values: the result may
+// still be edited, and the records attribute validates whatever is passed in.
+type parseZoneFileFunction struct{}
+
+func (f *parseZoneFileFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_zone_file"
+}
+
+func (f *parseZoneFileFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Parse an RFC 1035 zone file into DNS record objects",
+		MarkdownDescription: "Parses an RFC 1035 (BIND) zone file into a list of objects with the same attributes as the elements of `spaceship_dns_records.records`, so the result can be filtered or merged before it is assigned. Owner names are returned relative to `origin` (the apex as `@`), and fields that do not apply to a record's type are null. `$ORIGIN` and `$TTL` are honored and `SOA` records are skipped; unsupported record types, `$INCLUDE`, and syntax errors fail the call with their line number. Record values are not validated here — `spaceship_dns_records` validates them when the list is assigned to `records`.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "text",
+				MarkdownDescription: "Zone file content, typically read with `file()`.",
+			},
+			function.StringParameter{
+				Name:                "origin",
+				MarkdownDescription: "The zone's domain (for example `example.com`). It is the initial `$ORIGIN`, and every owner name must lie within it.",
+			},
+		},
+		Return: function.ListReturn{
+			ElementType: dnsRecordObjectType,
+		},
+	}
+}
+
+func (f *parseZoneFileFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var text, origin string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &text, &origin))
+	if resp.Error != nil {
+		return
+	}
+
+	if strings.Trim(strings.TrimSpace(origin), ".") == "" {
+		resp.Error = function.NewArgumentFuncError(1, "origin must be a domain name, for example \"example.com\"")
+		return
+	}
+
+	parsed, err := parseZoneFile(text, strings.TrimSpace(origin))
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, zoneFileErrorMessage(err))
+		return
+	}
+
+	elements := make([]attr.Value, 0, len(parsed))
+	for _, entry := range parsed {
+		object, diags := recordObjectValue(ctx, entry.Record)
+		if diags.HasError() {
+			resp.Error = function.FuncErrorFromDiags(ctx, diags)
+			return
+		}
+		elements = append(elements, object)
+	}
+
+	list, diags := types.ListValue(dnsRecordObjectType, elements)
+	if diags.HasError() {
+		resp.Error = function.FuncErrorFromDiags(ctx, diags)
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, list))
+}
+
+// zoneFileErrorMessage renders a parseZoneFile error for a function argument
+// error, one "Line N: ..." per problem.
+func zoneFileErrorMessage(err error) string {
+	var lineErrs zoneFileErrors
+	if !errors.As(err, &lineErrs) {
+		return "Invalid zone file: " + err.Error()
+	}
+	lines := make([]string, len(lineErrs))
+	for i, e := range lineErrs {
+		lines[i] = fmt.Sprintf("Line %d: %s", e.Line, e.Msg)
+	}
+	return "Invalid zone file:\n" + strings.Join(lines, "\n")
+}
+
+// recordObjectValue converts a record into a records-list element, keeping
+// only the attributes records.IsRecordField allows for its type, so the object
+// passes the irrelevant-fields validator when assigned to records.
+func recordObjectValue(ctx context.Context, record client.DNSRecord) (types.Object, diag.Diagnostics) {
+	var model dnsRecordModel
+	hydrateRecordModel(&model, record)
+
+	object, diags := types.ObjectValueFrom(ctx, dnsRecordObjectType.AttrTypes, model)
+	if diags.HasError() {
+		return object, diags
+	}
+
+	attrs := object.Attributes()
+	for name, value := range attrs {
+		if records.IsRecordField(record.Type, name) {
+			continue
+		}
+		switch value.(type) {
+		case types.String:
+			attrs[name] = types.StringNull()
+		case types.Int64:
+			attrs[name] = types.Int64Null()
+		}
+	}
+	return types.ObjectValue(dnsRecordObjectType.AttrTypes, attrs)
+}
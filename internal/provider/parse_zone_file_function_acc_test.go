@@ -0,0 +1,63 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccParseZoneFileFunction_basic(t *testing.T) {
+	testAccPreCheck(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "spaceship" {}
+
+locals {
+  records = provider::spaceship::parse_zone_file(<<-EOT
+    $TTL 600
+    @    IN A   192.0.2.10
+    www  IN TXT "hello" "world"
+  EOT
+  , "example.com")
+}
+
+output "names" {
+  value = join(",", [for r in local.records : "${r.type}:${r.name}"])
+}
+
+output "txt" {
+  value = local.records[1].value
+}
+
+output "ttl" {
+  value = local.records[0].ttl
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckOutput("names", "A:@,TXT:www"),
+					resource.TestCheckOutput("txt", "helloworld"),
+					resource.TestCheckOutput("ttl", "600"),
+				),
+			},
+			{
+				Config: `
+provider "spaceship" {}
+
+output "records" {
+  value = provider::spaceship::parse_zone_file("www IN SSHFP 1 1 abcd", "example.com")
+}
+`,
+				ExpectError: regexp.MustCompile(`(?s)Line 1:.*SSHFP`),
+			},
+		},
+	})
+}
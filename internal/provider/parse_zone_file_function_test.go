@@ -0,0 +1,87 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func runParseZoneFile(t *testing.T, text, origin string) *function.RunResponse {
+	t.Helper()
+	req := function.RunRequest{
+		Arguments: function.NewArgumentsData([]attr.Value{types.StringValue(text), types.StringValue(origin)}),
+	}
+	resp := &function.RunResponse{
+		Result: function.NewResultData(types.ListUnknown(dnsRecordObjectType)),
+	}
+	NewParseZoneFileFunction().Run(context.Background(), req, resp)
+	return resp
+}
+
+func TestParseZoneFileFunction_ReturnsRecordObjects(t *testing.T) {
+	zone := "@ 300 IN MX 10 mail\n_sip._tcp SRV 10 5 5060 sip.example.net.\n"
+
+	resp := runParseZoneFile(t, zone, "example.com")
+	if resp.Error != nil {
+		t.Fatalf("unexpected error: %s", resp.Error)
+	}
+
+	list, ok := resp.Result.Value().(types.List)
+	if !ok {
+		t.Fatalf("expected types.List result, got %T", resp.Result.Value())
+	}
+	var models []dnsRecordModel
+	if diags := list.ElementsAs(context.Background(), &models, false); diags.HasError() {
+		t.Fatalf("ElementsAs: %v", diags)
+	}
+	if len(models) != 2 {
+		t.Fatalf("expected 2 records, got %d", len(models))
+	}
+
+	mx := models[0]
+	if mx.Type.ValueString() != "MX" || mx.Name.ValueString() != "@" || mx.TTL.ValueInt64() != 300 ||
+		mx.Preference.ValueInt64() != 10 || mx.Exchange.ValueString() != "mail.example.com" {
+		t.Errorf("unexpected MX record: %+v", mx)
+	}
+
+	srv := models[1]
+	if srv.Service.ValueString() != "_sip" || srv.Protocol.ValueString() != "_tcp" || srv.PortNumber.ValueInt64() != 5060 || srv.Name.ValueString() != "@" {
+		t.Errorf("unexpected SRV record: %+v", srv)
+	}
+	// Fields of other types stay null so the objects pass the records
+	// irrelevant-fields validator unchanged.
+	if !srv.Port.IsNull() || !srv.Address.IsNull() || !mx.Value.IsNull() {
+		t.Errorf("expected foreign fields to be null, got port=%s address=%s value=%s", srv.Port, srv.Address, mx.Value)
+	}
+}
+
+func TestParseZoneFileFunction_PositionedErrors(t *testing.T) {
+	cases := map[string]struct {
+		text, origin string
+		argument     int64
+		message      string
+	}{
+		"unsupported type": {text: "www A 192.0.2.1\nwww SSHFP 1 1 abcd\n", origin: "example.com", argument: 0, message: "Line 2: record type SSHFP is not supported"},
+		"syntax error":     {text: "txt TXT \"open\n", origin: "example.com", argument: 0, message: "Line 1: unterminated quoted string"},
+		"empty origin":     {text: "www A 192.0.2.1\n", origin: " ", argument: 1, message: "origin must be a domain name"},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			resp := runParseZoneFile(t, tc.text, tc.origin)
+			if resp.Error == nil {
+				t.Fatal("expected an error")
+			}
+			if resp.Error.FunctionArgument == nil || *resp.Error.FunctionArgument != tc.argument {
+				t.Errorf("expected error on argument %d, got %v", tc.argument, resp.Error.FunctionArgument)
+			}
+			if !strings.Contains(resp.Error.Text, tc.message) {
+				t.Errorf("expected message containing %q, got %q", tc.message, resp.Error.Text)
+			}
+		})
+	}
+}
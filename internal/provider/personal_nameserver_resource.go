@@ -13,6 +13,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
@@ -28,6 +29,7 @@ var (
 	_ resource.Resource                = &personalNameserverResource{}
 	_ resource.ResourceWithConfigure   = &personalNameserverResource{}
 	_ resource.ResourceWithImportState = &personalNameserverResource{}
+	_ resource.ResourceWithIdentity    = &personalNameserverResource{}
 )
 
 // Every operation makes a single rate-limitable call (upsert, list fetch, or
@@ -57,8 +59,31 @@ type personalNameserverResourceModel struct {
 	Timeouts timeouts.Value `tfsdk:"timeouts"`
 }
 
+type personalNameserverIdentityModel struct {
+	Domain types.String `tfsdk:"domain"`
+	Host   types.String `tfsdk:"host"`
+}
+
 func (r *personalNameserverResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_personal_nameserver"
+	// A host change renames the nameserver in place (see Update), so the
+	// identity legitimately changes without a replace.
+	resp.ResourceBehavior.MutableIdentity = true
+}
+
+func (r *personalNameserverResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"domain": identityschema.StringAttribute{
+				RequiredForImport: true,
+				Description:       "The domain the nameserver host belongs to.",
+			},
+			"host": identityschema.StringAttribute{
+				RequiredForImport: true,
+				Description:       "The nameserver host label, relative to the domain (e.g. `ns1`).",
+			},
+		},
+	}
 }
 
 func (r *personalNameserverResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
@@ -169,6 +194,7 @@ func (r *personalNameserverResource) Create(ctx context.Context, req resource.Cr
 
 	resp.Diagnostics.Append(r.hydrate(ctx, &plan, domain, result)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, personalNameserverIdentityModel{Domain: plan.Domain, Host: plan.Host})...)
 }
 
 func (r *personalNameserverResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -216,6 +242,7 @@ func (r *personalNameserverResource) Read(ctx context.Context, req resource.Read
 
 	resp.Diagnostics.Append(r.hydrate(ctx, &state, domain, ns)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, personalNameserverIdentityModel{Domain: state.Domain, Host: state.Host})...)
 }
 
 func (r *personalNameserverResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -256,6 +283,7 @@ func (r *personalNameserverResource) Update(ctx context.Context, req resource.Up
 
 	resp.Diagnostics.Append(r.hydrate(ctx, &plan, domain, result)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, personalNameserverIdentityModel{Domain: plan.Domain, Host: plan.Host})...)
 }
 
 func (r *personalNameserverResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -290,7 +318,18 @@ func (r *personalNameserverResource) Delete(ctx context.Context, req resource.De
 func (r *personalNameserverResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	// Import string is the composite ID (domain/host). Read parses it and
 	// hydrates the remaining attributes.
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	if req.ID != "" {
+		resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+		return
+	}
+
+	var identity personalNameserverIdentityModel
+	resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	id := personalNameserverID(identity.Domain.ValueString(), identity.Host.ValueString())
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
 }
 
 // upsertWithRetry saves the nameserver via the shared create/rename/update
@@ -7,8 +7,11 @@ import (
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
 	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
 
 	"github.com/namecheap/go-spaceship-sdk/client"
 )
@@ -137,6 +140,50 @@ resource "spaceship_personal_nameserver" "test" {
 	})
 }
 
+// Import by identity: an import block with domain and host resolves the same
+// nameserver as the domain/host ID.
+func TestAccPersonalNameserver_importByIdentity(t *testing.T) {
+	testAccPreCheck(t)
+
+	domain := testAccDomainValue()
+	host := "ns1"
+	resourceName := "spaceship_personal_nameserver.test"
+
+	config := fmt.Sprintf(`
+provider "spaceship" {}
+
+resource "spaceship_personal_nameserver" "test" {
+  domain = %[1]q
+  host   = %[2]q
+  ips    = ["1.2.3.4"]
+}
+`, domain, host)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_12_0),
+		},
+		CheckDestroy: testAccCheckPersonalNameserverAbsent(domain, host),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectIdentity(resourceName, map[string]knownvalue.Check{
+						"domain": knownvalue.StringExact(domain),
+						"host":   knownvalue.StringExact(host),
+					}),
+				},
+			},
+			{
+				ResourceName:    resourceName,
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithResourceIdentity,
+			},
+		},
+	})
+}
+
 func testAccCheckPersonalNameserverAbsent(domain string, hosts ...string) resource.TestCheckFunc {
 	return func(*terraform.State) error {
 		testClient, err := testAccClient()
@@ -7,6 +7,8 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/list"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
@@ -29,7 +31,9 @@ func baseURL() string {
 
 // ensure spaceship provider satisfies expected interfaces
 var (
-	_ provider.Provider = &spaceshipProvider{}
+	_ provider.Provider                  = &spaceshipProvider{}
+	_ provider.ProviderWithFunctions     = &spaceshipProvider{}
+	_ provider.ProviderWithListResources = &spaceshipProvider{}
 )
 
 func New(version string) func() provider.Provider {
@@ -124,25 +128,30 @@ func (p *spaceshipProvider) Configure(ctx context.Context, req provider.Configur
 		"base_url": defaultBaseURL,
 	})
 
-	// All resources and data sources receive the same providerData: the raw
-	// client plus a shared DNS-record cache. The cache lives here (and not on
-	// the client) so the client stays a pure API client; it is per-process and
-	// thus naturally scoped to a single Terraform command.
+	// All resources, list resources and data sources receive the same
+	// providerData: the raw client plus a shared DNS-record cache and claim
+	// registry. Both live here (and not on the client) so the client stays a
+	// pure API client; they are per-process and thus naturally scoped to a
+	// single Terraform command.
 	pd := &providerData{
-		Client:     client,
-		DNSRecords: newDNSRecordCache(client),
+		Client:          client,
+		DNSRecords:      newDNSRecordCache(client),
+		DNSRecordClaims: newDNSRecordRegistry(),
 	}
 	resp.DataSourceData = pd
 	resp.ResourceData = pd
+	resp.ListResourceData = pd
 }
 
-// providerData is the shared dependency bundle handed to every resource and
-// data source through ProviderData. Resources that only talk to the API read
-// Client; the singular dns_record resource additionally uses DNSRecords to
-// collapse its many per-record reads into one fetch per domain.
+// providerData is the shared dependency bundle handed to every resource, list
+// resource and data source through ProviderData. Resources that only talk to
+// the API read Client; both DNS record resources additionally use DNSRecords
+// to collapse their zone reads into one fetch per domain, and DNSRecordClaims
+// to reject a record declared by two resources.
 type providerData struct {
-	Client     *client.Client
-	DNSRecords *dnsRecordCache
+	Client          *client.Client
+	DNSRecords      *dnsRecordCache
+	DNSRecordClaims *dnsRecordRegistry
 }
 
 func (p *spaceshipProvider) Resources(_ context.Context) []func() resource.Resource {
@@ -158,6 +167,21 @@ func (p *spaceshipProvider) DataSources(_ context.Context) []func() datasource.D
 	return []func() datasource.DataSource{
 		NewDomainListDataSource,
 		NewDomainInfoDataSource,
+		NewDomainExpirationsDataSource,
+		NewDNSZoneFileDataSource,
+	}
+}
+
+func (p *spaceshipProvider) ListResources(_ context.Context) []func() list.ListResource {
+	return []func() list.ListResource{
+		NewDNSRecordListResource,
+		NewDomainListResource,
+	}
+}
+
+func (p *spaceshipProvider) Functions(_ context.Context) []func() function.Function {
+	return []func() function.Function{
+		NewParseZoneFileFunction,
 	}
 }
 
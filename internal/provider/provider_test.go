@@ -67,3 +67,23 @@ func testAccCheckDNSRecordAbsent(domain, recordType, name string) resource.TestC
 		return nil
 	}
 }
+
+func testAccCheckDNSRecordPresent(domain, recordType, name string) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		testClient, err := testAccClient()
+		if err != nil {
+			return err
+		}
+		records, err := testClient.GetDNSRecords(context.Background(), domain)
+		if err != nil {
+			return err
+		}
+
+		for _, record := range records {
+			if strings.EqualFold(record.Type, recordType) && strings.EqualFold(record.Name, name) {
+				return nil
+			}
+		}
+		return fmt.Errorf("DNS record %s %s not found in domain %s", recordType, name, domain)
+	}
+}
@@ -49,6 +49,17 @@ var universalRecordFields = map[string]struct{}{
 	"type": {}, "name": {}, "ttl": {},
 }
 
+// IsRecordField reports whether the named record attribute belongs on a
+// record of recordType: the universal attributes always do, the rest
+// according to recordFieldsByType. Unknown types have no type-specific fields.
+func IsRecordField(recordType, name string) bool {
+	if _, ok := universalRecordFields[name]; ok {
+		return true
+	}
+	_, ok := recordFieldsByType[strings.ToUpper(recordType)][name]
+	return ok
+}
+
 type irrelevantFieldsValidator struct{}
 
 var _ validator.Object = &irrelevantFieldsValidator{}
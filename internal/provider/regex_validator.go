@@ -0,0 +1,34 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// validRegex is a validator that requires a string to compile as a Go (RE2)
+// regular expression.
+type validRegex struct{}
+
+func validRegexValidator() validator.String {
+	return validRegex{}
+}
+
+func (v validRegex) Description(_ context.Context) string {
+	return "value must be a valid RE2 regular expression"
+}
+
+func (v validRegex) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v validRegex) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	if _, err := regexp.Compile(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid regular expression", fmt.Sprintf("%q does not compile: %s", req.ConfigValue.ValueString(), err))
+	}
+}
@@ -0,0 +1,32 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+)
+
+// Every resource exposes a resource identity so Terraform 1.12+ import blocks
+// can address it by structured attributes.
+func TestResourceIdentitySchemas_Valid(t *testing.T) {
+	resources := map[string]fwresource.ResourceWithIdentity{
+		"spaceship_domain":              &domainResource{},
+		"spaceship_dns_record":          &dnsRecordResource{},
+		"spaceship_dns_records":         &dnsRecordsResource{},
+		"spaceship_personal_nameserver": &personalNameserverResource{},
+	}
+	for name, r := range resources {
+		resp := &fwresource.IdentitySchemaResponse{}
+		r.IdentitySchema(context.Background(), fwresource.IdentitySchemaRequest{}, resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("%s identity schema diagnostics: %v", name, resp.Diagnostics)
+		}
+		if diags := resp.IdentitySchema.ValidateImplementation(context.Background()); diags.HasError() {
+			t.Errorf("%s identity schema is invalid: %v", name, diags)
+		}
+		if _, ok := resp.IdentitySchema.Attributes["domain"]; !ok {
+			t.Errorf("expected a domain attribute in the %s identity schema", name)
+		}
+	}
+}